@@ -0,0 +1,67 @@
+package canvas
+
+import "time"
+
+// Announcements will get the course's announcements.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics.index
+func (c *Course) Announcements(opts ...Option) ([]*DiscussionTopic, error) {
+	return collectDiscussionTopics(c.AnnouncementsIter(opts...))
+}
+
+// AnnouncementsIter returns an iterator over the course's announcements.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics.index
+func (c *Course) AnnouncementsIter(opts ...Option) *DiscussionTopicIterator {
+	opts = append(opts, Opt("only_announcements", true))
+	return c.DiscussionTopicsIter(opts...)
+}
+
+// CreateAnnouncement will post an announcement to the course. If the
+// announcement's DelayedPostAt field is set then it will not be posted
+// until that time.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics.create
+func (c *Course) CreateAnnouncement(a DiscussionTopic, opts ...Option) (*DiscussionTopic, error) {
+	a.IsAnnouncement = true
+	return c.CreateDiscussionTopic(a, opts...)
+}
+
+// ScheduleAnnouncement will create an announcement in the course
+// that will be posted at a later time.
+func (c *Course) ScheduleAnnouncement(a DiscussionTopic, at time.Time, opts ...Option) (*DiscussionTopic, error) {
+	a.DelayedPostAt = at
+	return c.CreateAnnouncement(a, opts...)
+}
+
+// CreateAnnouncement will post the same announcement to each of the
+// courses. The announcements that were created are returned along with
+// the first error, if any, that stopped the rest from being posted.
+func (c *Canvas) CreateAnnouncement(a DiscussionTopic, courseIDs []int, opts ...Option) ([]*DiscussionTopic, error) {
+	created := make([]*DiscussionTopic, 0, len(courseIDs))
+	for _, id := range courseIDs {
+		course := &Course{ID: id, client: c.client, errorHandler: ConcurrentErrorHandler}
+		t, err := course.CreateAnnouncement(a, opts...)
+		if err != nil {
+			return created, err
+		}
+		created = append(created, t)
+	}
+	return created, nil
+}
+
+// CreateAnnouncement will post the same announcement to each of the courses
+// using the default client.
+func CreateAnnouncement(a DiscussionTopic, courseIDs []int, opts ...Option) ([]*DiscussionTopic, error) {
+	return ca.CreateAnnouncement(a, courseIDs, opts...)
+}
+
+// Lock will lock the topic so that no new entries can be posted.
+func (t *DiscussionTopic) Lock() error {
+	return t.send(put, t.path(), params{"locked": {"true"}})
+}
+
+// Unlock will unlock the topic so that entries can be posted again.
+func (t *DiscussionTopic) Unlock() error {
+	return t.send(put, t.path(), params{"locked": {"false"}})
+}
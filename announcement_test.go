@@ -0,0 +1,78 @@
+package canvas
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestCourse_Announcements(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	c := &Course{ID: 1, client: cli}
+	postAt := time.Date(2020, time.March, 1, 9, 0, 0, 0, time.UTC)
+
+	mux.HandleFunc("/api/v1/courses/1/discussion_topics", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.Method {
+		case "GET":
+			is.Equal(q.Get("only_announcements"), "true")
+			w.Write([]byte(`[{"id":5,"title":"Welcome","delayed_post_at":null}]`))
+		case "POST":
+			is.Equal(q.Get("title"), "Exam moved")
+			is.Equal(q.Get("is_announcement"), "true")
+			is.Equal(q.Get("delayed_post_at"), "2020-03-01T09:00:00Z")
+			is.Equal(len(q["lock_at"]), 0)
+			w.Write([]byte(`{"id":6,"title":"Exam moved","is_announcement":true,"delayed_post_at":"2020-03-01T09:00:00Z"}`))
+		}
+	})
+	var query map[string][]string
+	mux.HandleFunc("/api/v1/courses/1/discussion_topics/6", func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(r.Method, "PUT")
+		query = r.URL.Query()
+		w.Write([]byte(`{"id":6,"locked":` + query["locked"][0] + `}`))
+	})
+
+	list, err := c.Announcements()
+	is.NoErr(err)
+	is.Equal(len(list), 1)
+	is.True(list[0].DelayedPostAt.IsZero())
+
+	a, err := c.ScheduleAnnouncement(DiscussionTopic{Title: "Exam moved"}, postAt)
+	is.NoErr(err)
+	is.True(a.IsAnnouncement)
+	is.True(a.DelayedPostAt.Equal(postAt))
+
+	is.NoErr(a.Lock())
+	is.True(a.Locked)
+	is.Equal(len(query), 1) // only the locked flag is sent
+	is.NoErr(a.Unlock())
+	is.Equal(query["locked"], []string{"false"})
+	is.True(!a.Locked)
+}
+
+func TestCreateAnnouncement(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	defer swapCanvas(&Canvas{client: cli})()
+
+	for _, p := range []string{"/api/v1/courses/1/discussion_topics", "/api/v1/courses/2/discussion_topics"} {
+		mux.HandleFunc(p, func(w http.ResponseWriter, r *http.Request) {
+			is.Equal(r.Method, "POST")
+			is.Equal(r.URL.Query().Get("is_announcement"), "true")
+			w.Write([]byte(`{"id":1,"title":"No class","is_announcement":true}`))
+		})
+	}
+	list, err := CreateAnnouncement(DiscussionTopic{Title: "No class"}, []int{1, 2})
+	is.NoErr(err)
+	is.Equal(len(list), 2)
+	is.Equal(list[1].path(), "/courses/2/discussion_topics/1")
+
+	list, err = CreateAnnouncement(DiscussionTopic{Title: "No class"}, []int{1, 3})
+	is.True(err != nil)
+	is.Equal(len(list), 1)
+}
@@ -0,0 +1,183 @@
+package canvas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/harrybrwn/go-querystring/query"
+)
+
+// AssignmentGroup is a group of assignments in a course. Group
+// weights are only used if the course has ApplyAssignmentGroupWeights
+// set.
+//
+// https://canvas.instructure.com/doc/api/assignment_groups.html
+type AssignmentGroup struct {
+	ID       int    `json:"id" url:"-"`
+	Name     string `json:"name" url:"name,omitempty"`
+	Position int    `json:"position" url:"position,omitempty"`
+	// GroupWeight is the percent of the final grade
+	// that the group is worth.
+	GroupWeight     float64           `json:"group_weight" url:"group_weight,omitempty"`
+	SisSourceID     string            `json:"sis_source_id" url:"sis_source_id,omitempty"`
+	IntegrationData map[string]string `json:"integration_data" url:"-"`
+	// Assignments is only set if the group was requested
+	// with the "assignments" include option.
+	Assignments []*Assignment `json:"assignments" url:"-"`
+	Rules       GradingRules  `json:"rules" url:"rules"`
+
+	courseID int
+	client   doer
+}
+
+// GradingRules are the rules for dropping scores
+// in an assignment group.
+type GradingRules struct {
+	// DropLowest is the number of lowest scores to drop.
+	DropLowest int `json:"drop_lowest" url:"drop_lowest,omitempty"`
+	// DropHighest is the number of highest scores to drop.
+	DropHighest int `json:"drop_highest" url:"drop_highest,omitempty"`
+	// NeverDrop is a list of assignment ids that will never be dropped.
+	NeverDrop []int `json:"never_drop" url:"never_drop,brackets,omitempty"`
+}
+
+// AssignmentGroups returns a channel of the course's assignment groups.
+// Use IncludeOpt("assignments", "submission") to get each group's
+// assignments along with the current user's submissions.
+//
+// https://canvas.instructure.com/doc/api/assignment_groups.html#method.assignment_groups.index
+func (c *Course) AssignmentGroups(opts ...Option) <-chan *AssignmentGroup {
+	it := c.AssignmentGroupsIter(opts...)
+	it.handler = c.errorHandler
+	ch := make(chan *AssignmentGroup)
+	stream(it.iterator, ch)
+	return ch
+}
+
+// AssignmentGroupsIter returns an iterator over the course's assignment groups.
+//
+// https://canvas.instructure.com/doc/api/assignment_groups.html#method.assignment_groups.index
+func (c *Course) AssignmentGroupsIter(opts ...Option) *AssignmentGroupIterator {
+	return &AssignmentGroupIterator{newIterator(c.client, c.id("/courses/%d/assignment_groups"), opts, func(r io.Reader, emit emitFunc) error {
+		list := make([]*AssignmentGroup, 0, defaultPerPage)
+		if err := json.NewDecoder(r).Decode(&list); err != nil {
+			return err
+		}
+		for _, g := range list {
+			g.setclient(c.client, c.ID)
+			if err := emit(g); err != nil {
+				return err
+			}
+		}
+		return nil
+	})}
+}
+
+// ListAssignmentGroups returns a slice of the course's assignment groups.
+//
+// https://canvas.instructure.com/doc/api/assignment_groups.html#method.assignment_groups.index
+func (c *Course) ListAssignmentGroups(opts ...Option) ([]*AssignmentGroup, error) {
+	it := c.AssignmentGroupsIter(opts...)
+	groups := make([]*AssignmentGroup, 0)
+	err := collect(it.iterator, &groups)
+	return groups, err
+}
+
+// AssignmentGroup will get one of the course's assignment groups.
+//
+// https://canvas.instructure.com/doc/api/assignment_groups.html#method.assignment_groups_api.show
+func (c *Course) AssignmentGroup(id int, opts ...Option) (*AssignmentGroup, error) {
+	g := &AssignmentGroup{}
+	if err := getjson(c.client, g, optEnc(opts), "/courses/%d/assignment_groups/%d", c.ID, id); err != nil {
+		return nil, err
+	}
+	g.setclient(c.client, c.ID)
+	return g, nil
+}
+
+// CreateAssignmentGroup will create a new assignment group in the course.
+//
+// https://canvas.instructure.com/doc/api/assignment_groups.html#method.assignment_groups_api.create
+func (c *Course) CreateAssignmentGroup(g AssignmentGroup, opts ...Option) (*AssignmentGroup, error) {
+	q, err := query.Values(&g)
+	if err != nil {
+		return nil, err
+	}
+	params(q).Add(opts)
+	group := &AssignmentGroup{}
+	if err = send(c.client, post, c.id("/courses/%d/assignment_groups"), q, group); err != nil {
+		return nil, err
+	}
+	group.setclient(c.client, c.ID)
+	return group, nil
+}
+
+// WithContext returns a copy of the assignment group that uses ctx.
+func (g *AssignmentGroup) WithContext(ctx context.Context) *AssignmentGroup {
+	cp := *g
+	cp.client = withContext(g.client, ctx)
+	return &cp
+}
+
+// Update will send the group's fields to canvas and replace them with
+// the updated group. Options can be used to send values that are left
+// out when they are empty (ex. Opt("rules[drop_lowest]", 0)).
+//
+// https://canvas.instructure.com/doc/api/assignment_groups.html#method.assignment_groups_api.update
+func (g *AssignmentGroup) Update(opts ...Option) error {
+	q, err := query.Values(g)
+	if err != nil {
+		return err
+	}
+	params(q).Add(opts)
+	return g.send(put, g.path(), q)
+}
+
+// Delete will delete the group along with its assignments. Use
+// Opt("move_assignments_to", id) to move the assignments into
+// another group instead.
+//
+// https://canvas.instructure.com/doc/api/assignment_groups.html#method.assignment_groups_api.destroy
+func (g *AssignmentGroup) Delete(opts ...Option) error {
+	resp, err := delete(g.client, g.path(), optEnc(opts))
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (g *AssignmentGroup) path() string {
+	return fmt.Sprintf("/courses/%d/assignment_groups/%d", g.courseID, g.ID)
+}
+
+func (g *AssignmentGroup) send(
+	method func(doer, string, encoder) (*http.Response, error),
+	path string,
+	q encoder,
+) error {
+	if err := send(g.client, method, path, q, g); err != nil {
+		return err
+	}
+	g.setclient(g.client, g.courseID)
+	return nil
+}
+
+func (g *AssignmentGroup) setclient(d doer, courseID int) {
+	g.client = d
+	g.courseID = courseID
+	for _, a := range g.Assignments {
+		a.client = d
+	}
+}
+
+// AssignmentGroupIterator iterates over a paginated list of assignment groups.
+type AssignmentGroupIterator struct{ *iterator }
+
+// Value returns the current assignment group.
+func (it *AssignmentGroupIterator) Value() *AssignmentGroup {
+	g, _ := it.cur.(*AssignmentGroup)
+	return g
+}
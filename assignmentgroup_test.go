@@ -0,0 +1,75 @@
+package canvas
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestCourse_AssignmentGroups(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	c := &Course{ID: 1, client: cli}
+
+	mux.HandleFunc("/api/v1/courses/1/assignment_groups", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.Method {
+		case "GET":
+			is.Equal(q["include[]"], []string{"assignments", "submission"})
+			w.Write([]byte(`[{"id":3,"name":"Homework","group_weight":40,
+				"rules":{"drop_lowest":1,"never_drop":[9]},
+				"assignments":[{"id":9,"name":"hw1","course_id":1}]}]`))
+		case "POST":
+			is.Equal(q.Get("name"), "Exams")
+			is.Equal(q.Get("group_weight"), "60")
+			is.Equal(q.Get("rules[drop_highest]"), "1")
+			is.Equal(q["rules[never_drop][]"], []string{"4", "5"})
+			is.Equal(len(q["rules[drop_lowest]"]), 0)
+			w.Write([]byte(`{"id":4,"name":"Exams","group_weight":60,"rules":{"drop_highest":1,"never_drop":[4,5]}}`))
+		}
+	})
+	var method string
+	mux.HandleFunc("/api/v1/courses/1/assignment_groups/4", func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		q := r.URL.Query()
+		switch r.Method {
+		case "PUT":
+			is.Equal(q.Get("group_weight"), "50")
+			is.Equal(q.Get("rules[drop_highest]"), "0")
+			w.Write([]byte(`{"id":4,"name":"Exams","group_weight":50,"rules":{"never_drop":[4,5]}}`))
+		case "DELETE":
+			is.Equal(q.Get("move_assignments_to"), "3")
+			w.Write([]byte(`{"id":4}`))
+		}
+	})
+
+	groups, err := c.ListAssignmentGroups(IncludeOpt("assignments", "submission"))
+	is.NoErr(err)
+	is.Equal(len(groups), 1)
+	is.Equal(groups[0].GroupWeight, 40.0)
+	is.Equal(groups[0].Rules.DropLowest, 1)
+	is.Equal(groups[0].Rules.NeverDrop, []int{9})
+	is.Equal(len(groups[0].Assignments), 1)
+	is.True(groups[0].Assignments[0].client != nil)
+
+	g, err := c.CreateAssignmentGroup(AssignmentGroup{
+		Name:        "Exams",
+		GroupWeight: 60,
+		Rules:       GradingRules{DropHighest: 1, NeverDrop: []int{4, 5}},
+	})
+	is.NoErr(err)
+	is.Equal(g.ID, 4)
+	is.Equal(g.path(), "/courses/1/assignment_groups/4")
+
+	g.GroupWeight = 50
+	g.Rules.DropHighest = 0
+	is.NoErr(g.Update(Opt("rules[drop_highest]", 0)))
+	is.Equal(method, "PUT")
+	is.Equal(g.GroupWeight, 50.0)
+	is.Equal(g.Rules.DropHighest, 0)
+
+	is.NoErr(g.Delete(Opt("move_assignments_to", 3)))
+	is.Equal(method, "DELETE")
+}
@@ -1,48 +1,52 @@
 package canvas
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"path"
-	"strings"
-
-	"github.com/harrybrwn/errs"
 )
 
-var (
-	// ErrRateLimitExceeded is returned when the api rate limit has been reached.
-	ErrRateLimitExceeded = errors.New("403 Forbidden (Rate Limit Exceeded)")
+var apiPath = "/api/v1"
 
-	apiPath = "/api/v1"
-)
+type doer interface {
+	Do(*http.Request) (*http.Response, error)
+}
 
-// IsRateLimit returns true if the error
-// given is a rate limit error.
-func IsRateLimit(e error) bool {
-	if e == ErrRateLimitExceeded {
-		return true
-	}
-	return false
+// ctxDoer is a doer that sends every request with a context.
+type ctxDoer struct {
+	doer
+	ctx context.Context
 }
 
-type client struct {
-	http.Client
-	host string
+func (cd *ctxDoer) Do(r *http.Request) (*http.Response, error) {
+	return cd.doer.Do(r.WithContext(cd.ctx))
 }
 
-func (c *client) Do(r *http.Request) (*http.Response, error) {
-	if r.URL.Host == "" {
-		r.Host = c.host
-		r.URL.Host = c.host
+// withContext wraps a doer so that all of its requests
+// will be sent using ctx.
+func withContext(d doer, ctx context.Context) doer {
+	if ctx == nil {
+		panic("nil context")
+	}
+	if cd, ok := d.(*ctxDoer); ok {
+		d = cd.doer
 	}
-	return c.Client.Do(r)
+	return &ctxDoer{doer: d, ctx: ctx}
 }
 
-type doer interface {
-	Do(*http.Request) (*http.Response, error)
+// contextOf returns the context that a doer is sending its requests with.
+func contextOf(d doer) context.Context {
+	if cd, ok := d.(*ctxDoer); ok {
+		return cd.ctx
+	}
+	return context.Background()
 }
 
 func do(d doer, req *http.Request) (*http.Response, error) {
@@ -51,23 +55,13 @@ func do(d doer, req *http.Request) (*http.Response, error) {
 		return nil, err
 	}
 
-	var e error
 	switch resp.StatusCode {
-	case http.StatusOK, http.StatusCreated, http.StatusAccepted:
+	case http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusNoContent:
 		return resp, err
-	case http.StatusForbidden:
-		resp.Body.Close()
-		return nil, ErrRateLimitExceeded
-	case http.StatusUnprocessableEntity:
-		return nil, errs.Pair(resp.Body.Close(), errs.New(resp.Status))
-	case http.StatusNotFound, http.StatusUnauthorized:
-		e = &AuthError{}
-	case http.StatusBadRequest, http.StatusInternalServerError:
-		e = &Error{Status: resp.Status}
-	default:
-		e = &Error{Status: resp.Status}
 	}
-	return nil, errs.Chain(e, json.NewDecoder(resp.Body).Decode(&e), resp.Body.Close())
+	e := newAPIError(resp)
+	resp.Body.Close()
+	return nil, e
 }
 
 func get(c doer, endpoint string, vals encoder) (*http.Response, error) {
@@ -106,6 +100,44 @@ func newV1Req(method, urlpath, query string) *http.Request {
 	}
 }
 
+// send makes a request and decodes the response into obj.
+func send(
+	d doer,
+	method func(doer, string, encoder) (*http.Response, error),
+	path string,
+	q encoder,
+	obj interface{},
+) error {
+	resp, err := method(d, path, q)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(obj)
+}
+
+// sendJSON makes a request with body encoded as json
+// and decodes the response into obj.
+func sendJSON(d doer, method, path string, q encoder, body, obj interface{}) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req := newreq(method, path, q)
+	req.Body = ioutil.NopCloser(bytes.NewReader(b))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(b)), nil
+	}
+	req.ContentLength = int64(len(b))
+	req.Header = http.Header{"Content-Type": {"application/json"}}
+	resp, err := do(d, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(obj)
+}
+
 func getjson(
 	client doer,
 	obj interface{},
@@ -127,79 +159,79 @@ func authorize(c *http.Client, token, host string) {
 		rt = c.Transport
 	}
 	c.Transport = &auth{
-		rt:    rt,
+		rt:    newRetrier(rt),
 		token: token,
 		host:  host,
 	}
 }
 
+var errNoTransport = errors.New("could not find the canvas transport")
+
+// transport will find the auth round tripper being used by a doer.
+func transport(d doer) (*auth, bool) {
+	var c *http.Client
+	if cl, ok := baseClient(d); ok {
+		c = &cl.Client
+	} else if hc, ok := d.(*http.Client); ok {
+		c = hc
+	} else {
+		return nil, false
+	}
+	a, ok := c.Transport.(*auth)
+	return a, ok
+}
+
 type auth struct {
 	rt    http.RoundTripper
 	token string
 	host  string
+	// if empty, DefaultUserAgent is used
+	userAgent string
+	// if not nil, tokens are taken from src instead of using token
+	src TokenSource
 }
 
 func (a *auth) RoundTrip(req *http.Request) (*http.Response, error) {
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", a.token))
-	req.Header.Set("User-Agent", DefaultUserAgent)
+	agent := a.userAgent
+	if agent == "" {
+		agent = DefaultUserAgent
+	}
+	req.Header.Set("User-Agent", agent)
 	if req.URL.Host == "" {
 		// TODO: don't do this, it has caused my too much pain
 		req.Host = a.host
 		req.URL.Host = a.host
 	}
-	return a.rt.RoundTrip(req)
-}
-
-func checkErrors(errs []errorMsg) string {
-	if len(errs) < 1 {
-		return ""
+	if a.src == nil {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", a.token))
+		return a.rt.RoundTrip(req)
 	}
-	msgs := make([]string, len(errs))
-	for i := 0; i < len(errs); i++ {
-		msgs[i] = fmt.Sprintf("%s", errs[i].Message)
-	}
-	return strings.Join(msgs, ", ")
-}
-
-// Error is an error response.
-type Error struct {
-	Errors struct {
-		EndDate string `json:"end_date"`
-	} `json:"errors"`
-	Message string `json:"message"`
 
-	Err      string `json:"error"`
-	SentryID string `json:"sentryId"`
-
-	Status string `json:"-"`
-}
-
-func (e *Error) Error() string {
-	if e.Message != "" {
-		return e.Message
+	tok, err := sourceToken(req.Context(), a.src)
+	if err != nil {
+		return nil, err
 	}
-	if e.Errors.EndDate != "" {
-		return fmt.Sprintf("end_date: %s", e.Errors.EndDate)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tok.AccessToken))
+	resp, err := a.rt.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
 	}
-	if e.SentryID != "" {
-		return fmt.Sprintf("error status: %s; sentryId: %s", e.Err, e.SentryID)
+	// the token may have been revoked or expired early,
+	// refresh it and try one more time
+	r, ok := a.src.(refresher)
+	if !ok {
+		return resp, nil
 	}
-	return fmt.Sprintf("canvas error: %#v", e)
-}
-
-// AuthError is an authentication error response from canvas.
-type AuthError struct {
-	Status string     `json:"status"`
-	Errors []errorMsg `json:"errors"`
-}
-
-func (ae *AuthError) Error() string {
-	if ae.Status == "" {
-		return checkErrors(ae.Errors)
+	next, ok := rewind(req)
+	if !ok {
+		return resp, nil
 	}
-	return fmt.Sprintf("%s: %s", ae.Status, checkErrors(ae.Errors))
-}
-
-type errorMsg struct {
-	Message string `json:"message,omitempty"`
+	if tok, err = r.refresh(req.Context(), tok); err != nil {
+		return resp, nil
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+	next.Header = req.Header.Clone()
+	next.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tok.AccessToken))
+	return a.rt.RoundTrip(next)
 }
@@ -0,0 +1,317 @@
+package canvas
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/harrybrwn/go-querystring/query"
+)
+
+// These are used with the "which" option when updating or deleting
+// an event that is part of a recurring series.
+const (
+	// OneEvent only changes the event given.
+	OneEvent = "one"
+	// AllEvents changes every event in the series.
+	AllEvents = "all"
+	// FollowingEvents changes the event given and every
+	// event after it in the series.
+	FollowingEvents = "following"
+)
+
+// CalendarEvents makes a call to get calendar events.
+func (c *Canvas) CalendarEvents(opts ...Option) ([]*CalendarEvent, error) {
+	it := c.CalendarEventsIter(opts...)
+	events := make([]*CalendarEvent, 0)
+	err := collect(it.iterator, &events)
+	return events, err
+}
+
+// CalendarEventsIter returns an iterator over calendar events.
+func (c *Canvas) CalendarEventsIter(opts ...Option) *CalendarEventIterator {
+	return &CalendarEventIterator{newIterator(c.client, "/calendar_events", opts, func(r io.Reader, emit emitFunc) error {
+		evs := make([]*CalendarEvent, 0)
+		if err := json.NewDecoder(r).Decode(&evs); err != nil {
+			return err
+		}
+		for _, e := range evs {
+			if err := emit(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	})}
+}
+
+// CalendarEventIterator iterates over a paginated list of calendar events.
+type CalendarEventIterator struct{ *iterator }
+
+// Value returns the current calendar event.
+func (it *CalendarEventIterator) Value() *CalendarEvent {
+	e, _ := it.cur.(*CalendarEvent)
+	return e
+}
+
+// CalendarEvents makes a call to get calendar events.
+func CalendarEvents(opts ...Option) ([]*CalendarEvent, error) {
+	return ca.CalendarEvents(opts...)
+}
+
+type calendarEventOptions struct {
+	CalendarEvent `url:"calendar_event"`
+}
+
+// CreateCalendarEvent will send a calendar event to canvas to be created.
+// Set the event's Duplicate or RRule fields to create a recurring series
+// and set SectionEvents to give each section its own times. The other
+// events in a series are returned in the Duplicates field.
+//
+// https://canvas.instructure.com/doc/api/all_resources.html#method.calendar_events_api.create
+func (c *Canvas) CreateCalendarEvent(event *CalendarEvent) (*CalendarEvent, error) {
+	q, err := query.Values(&calendarEventOptions{*event})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := post(c.client, "/calendar_events", q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	cal := &CalendarEvent{}
+	var res struct {
+		*CalendarEvent
+		Duplicates []struct {
+			Event *CalendarEvent `json:"calendar_event"`
+		} `json:"duplicates"`
+	}
+	res.CalendarEvent = cal
+	if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, err
+	}
+	for _, d := range res.Duplicates {
+		cal.Duplicates = append(cal.Duplicates, d.Event)
+	}
+	return cal, nil
+}
+
+// CreateCalendarEvent will send a calendar event to canvas to be created.
+// https://canvas.instructure.com/doc/api/all_resources.html#method.calendar_events_api.create
+func CreateCalendarEvent(event *CalendarEvent) (*CalendarEvent, error) {
+	return ca.CreateCalendarEvent(event)
+}
+
+// UpdateCalendarEvent will update a calendar event. This operation will change
+// event given as an argument.
+// https://canvas.instructure.com/doc/api/all_resources.html#method.calendar_events_api.update
+func (c *Canvas) UpdateCalendarEvent(event *CalendarEvent, opts ...Option) error {
+	q, err := query.Values(&calendarEventOptions{*event})
+	if err != nil {
+		return err
+	}
+	params(q).Add(opts)
+	resp, err := put(c.client, fmt.Sprintf("/calendar_events/%d", event.ID), q)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(event)
+}
+
+// UpdateCalendarEvent will update a calendar event. This operation will change
+// event given as an argument.
+// https://canvas.instructure.com/doc/api/all_resources.html#method.calendar_events_api.update
+func UpdateCalendarEvent(event *CalendarEvent, opts ...Option) error {
+	return ca.UpdateCalendarEvent(event, opts...)
+}
+
+// UpdateCalendarSeries will update the events in the series that the
+// event belongs to. The which argument is one of OneEvent, AllEvents,
+// or FollowingEvents. The updated events are returned.
+// https://canvas.instructure.com/doc/api/all_resources.html#method.calendar_events_api.update
+func (c *Canvas) UpdateCalendarSeries(event *CalendarEvent, which string, opts ...Option) ([]*CalendarEvent, error) {
+	q, err := query.Values(&calendarEventOptions{*event})
+	if err != nil {
+		return nil, err
+	}
+	q.Set("which", which)
+	params(q).Add(opts)
+	return seriesRequest(c.client, put, event.ID, q)
+}
+
+// UpdateCalendarSeries will update the events in the series that the
+// event belongs to.
+// https://canvas.instructure.com/doc/api/all_resources.html#method.calendar_events_api.update
+func UpdateCalendarSeries(event *CalendarEvent, which string, opts ...Option) ([]*CalendarEvent, error) {
+	return ca.UpdateCalendarSeries(event, which, opts...)
+}
+
+// DeleteCalendarEventByID will delete a calendar event given its ID.
+// This operation returns the calendar event that was deleted.
+func (c *Canvas) DeleteCalendarEventByID(id int, opts ...Option) (*CalendarEvent, error) {
+	resp, err := delete(c.client, fmt.Sprintf("/calendar_events/%d", id), optEnc(opts))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	e := &CalendarEvent{}
+	return e, json.NewDecoder(resp.Body).Decode(e)
+}
+
+// DeleteCalendarEventByID will delete a calendar event given its ID.
+// This operation returns the calendar event that was deleted.
+func DeleteCalendarEventByID(id int, opts ...Option) (*CalendarEvent, error) {
+	return ca.DeleteCalendarEventByID(id, opts...)
+}
+
+// DeleteCalendarEvent will delete the calendar event and
+// return the calendar event deleted.
+func (c *Canvas) DeleteCalendarEvent(e *CalendarEvent) (*CalendarEvent, error) {
+	return c.DeleteCalendarEventByID(e.ID)
+}
+
+// DeleteCalendarEvent will delete the calendar event and
+// return the calendar event deleted.
+func DeleteCalendarEvent(e *CalendarEvent) (*CalendarEvent, error) {
+	return ca.DeleteCalendarEventByID(e.ID)
+}
+
+// DeleteCalendarSeries will delete the events in the series that the
+// event belongs to. The which argument is one of OneEvent, AllEvents,
+// or FollowingEvents. The deleted events are returned.
+func (c *Canvas) DeleteCalendarSeries(e *CalendarEvent, which string, opts ...Option) ([]*CalendarEvent, error) {
+	q := params{"which": {which}}
+	q.Add(opts)
+	return seriesRequest(c.client, delete, e.ID, q)
+}
+
+// DeleteCalendarSeries will delete the events in the series that the
+// event belongs to.
+func DeleteCalendarSeries(e *CalendarEvent, which string, opts ...Option) ([]*CalendarEvent, error) {
+	return ca.DeleteCalendarSeries(e, which, opts...)
+}
+
+// seriesRequest sends a request for an event in a series. Canvas
+// responds with a list of events when more than one event was
+// changed and a single event otherwise.
+func seriesRequest(
+	d doer,
+	method func(doer, string, encoder) (*http.Response, error),
+	id int,
+	q encoder,
+) ([]*CalendarEvent, error) {
+	resp, err := method(d, fmt.Sprintf("/calendar_events/%d", id), q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*CalendarEvent, 0)
+	if raw = bytes.TrimSpace(raw); len(raw) > 0 && raw[0] == '[' {
+		return events, json.Unmarshal(raw, &events)
+	}
+	e := &CalendarEvent{}
+	if err = json.Unmarshal(raw, e); err != nil {
+		return nil, err
+	}
+	return append(events, e), nil
+}
+
+// CalendarEvent is a calendar event
+type CalendarEvent struct {
+	ID                         int              `json:"id" url:"-"`
+	Title                      string           `json:"title" url:"title,omitempty"`
+	ContextCode                string           `json:"context_code" url:"context_code,omitempty"`
+	StartAt                    time.Time        `json:"start_at" url:"start_at,omitempty"`
+	EndAt                      time.Time        `json:"end_at" url:"end_at,omitempty"`
+	CreatedAt                  time.Time        `json:"created_at" url:"-"`
+	UpdatedAt                  time.Time        `json:"updated_at" url:"-"`
+	Description                string           `json:"description" url:"description,omitempty"`
+	LocationName               string           `json:"location_name" url:"location_name,omitempty"`
+	LocationAddress            string           `json:"location_address" url:"location_address,omitempty"`
+	EffectiveContextCode       interface{}      `json:"effective_context_code" url:"effective_context_code,omitempty"`
+	AllDay                     bool             `json:"all_day" url:"all_day,omitempty"`
+	AllContextCodes            string           `json:"all_context_codes" url:"-"`
+	WorkflowState              string           `json:"workflow_state" url:"-"`
+	Hidden                     bool             `json:"hidden" url:"-"`
+	ParentEventID              int              `json:"parent_event_id" url:"-"`
+	ChildEventsCount           int              `json:"child_events_count" url:"-"`
+	ChildEvents                []*CalendarEvent `json:"child_events" url:"-"`
+	URL                        string           `json:"url" url:"-"`
+	HTMLURL                    string           `json:"html_url" url:"-"`
+	AllDayDate                 string           `json:"all_day_date" url:"-"`
+	AppointmentGroupID         interface{}      `json:"appointment_group_id" url:"-"`
+	AppointmentGroupURL        string           `json:"appointment_group_url" url:"-"`
+	OwnReservation             bool             `json:"own_reservation" url:"-"`
+	ReserveURL                 string           `json:"reserve_url" url:"-"`
+	Reserved                   bool             `json:"reserved" url:"-"`
+	ParticipantType            string           `json:"participant_type" url:"-"`
+	ParticipantsPerAppointment interface{}      `json:"participants_per_appointment" url:"-"`
+	AvailableSlots             interface{}      `json:"available_slots" url:"-"`
+	User                       *User            `json:"user" url:"-"`
+	Group                      interface{}      `json:"group" url:"-"`
+
+	// RRule is an iCalendar recurrence rule (ex. "FREQ=WEEKLY;COUNT=10")
+	// used to create a series on newer versions of canvas.
+	RRule string `json:"rrule" url:"rrule,omitempty"`
+	// SeriesUUID is shared by every event in a series.
+	SeriesUUID            string `json:"series_uuid" url:"-"`
+	SeriesHead            bool   `json:"series_head" url:"-"`
+	SeriesNaturalLanguage string `json:"series_natural_language" url:"-"`
+
+	// Duplicate is used to repeat the event when it is created.
+	Duplicate EventDuplicate `json:"-" url:"duplicate"`
+	// SectionEvents are sent when creating or updating an event
+	// to give sections their own times. Canvas returns these as
+	// ChildEvents.
+	SectionEvents SectionEvents `json:"-" url:"child_event_data,omitempty"`
+	// Duplicates are the other events in the series
+	// returned when the event is created.
+	Duplicates []*CalendarEvent `json:"-" url:"-"`
+}
+
+// EventDuplicate describes how an event is repeated when it is created.
+type EventDuplicate struct {
+	// Count is the number of times to copy the event.
+	Count int `url:"count,omitempty"`
+	// Interval is the number of Frequency units between events.
+	Interval int `url:"interval,omitempty"`
+	// Frequency is "daily", "weekly", or "monthly".
+	Frequency string `url:"frequency,omitempty"`
+	// AppendIterator adds a number to the title of each event.
+	AppendIterator bool `url:"append_iterator,omitempty"`
+}
+
+// SectionEvent is the time of an event for one section.
+type SectionEvent struct {
+	// ContextCode is the section's context code (ex. "course_section_4").
+	ContextCode string    `url:"context_code"`
+	StartAt     time.Time `url:"start_at,omitempty"`
+	EndAt       time.Time `url:"end_at,omitempty"`
+}
+
+// SectionEvents is a list of section event times.
+type SectionEvents []SectionEvent
+
+// EncodeValues encodes the events using their index
+// (ex. calendar_event[child_event_data][0][start_at]).
+func (se SectionEvents) EncodeValues(key string, v *url.Values) error {
+	for i, e := range se {
+		vals, err := query.Values(&e)
+		if err != nil {
+			return err
+		}
+		for k, val := range vals {
+			(*v)[fmt.Sprintf("%s[%d][%s]", key, i, k)] = val
+		}
+	}
+	return nil
+}
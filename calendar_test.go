@@ -0,0 +1,84 @@
+package canvas
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestCreateCalendarEvent_Series(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	defer swapCanvas(&Canvas{client: cli})()
+	start := time.Date(2020, time.March, 2, 15, 0, 0, 0, time.UTC)
+
+	mux.HandleFunc("/api/v1/calendar_events", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		is.Equal(q.Get("calendar_event[title]"), "Office Hours")
+		is.Equal(q.Get("calendar_event[duplicate][count]"), "2")
+		is.Equal(q.Get("calendar_event[duplicate][frequency]"), "weekly")
+		is.Equal(q.Get("calendar_event[duplicate][interval]"), "1")
+		is.Equal(len(q["calendar_event[duplicate][append_iterator]"]), 0)
+		is.Equal(q.Get("calendar_event[child_event_data][0][context_code]"), "course_section_4")
+		is.Equal(q.Get("calendar_event[child_event_data][0][start_at]"), "2020-03-02T15:00:00Z")
+		is.Equal(q.Get("calendar_event[child_event_data][1][context_code]"), "course_section_5")
+		is.Equal(len(q["calendar_event[rrule]"]), 0)
+		w.Write([]byte(`{"id":1,"title":"Office Hours","child_events_count":2,"series_uuid":"abc",
+			"child_events":[{"id":2,"parent_event_id":1,"context_code":"course_section_4"},
+				{"id":3,"parent_event_id":1,"context_code":"course_section_5"}],
+			"duplicates":[{"calendar_event":{"id":4,"series_uuid":"abc"}},{"calendar_event":{"id":7,"series_uuid":"abc"}}]}`))
+	})
+
+	e, err := CreateCalendarEvent(&CalendarEvent{
+		Title:       "Office Hours",
+		ContextCode: "course_1",
+		Duplicate:   EventDuplicate{Count: 2, Interval: 1, Frequency: "weekly"},
+		SectionEvents: SectionEvents{
+			{ContextCode: "course_section_4", StartAt: start, EndAt: start.Add(time.Hour)},
+			{ContextCode: "course_section_5", StartAt: start.Add(2 * time.Hour)},
+		},
+	})
+	is.NoErr(err)
+	is.Equal(e.ID, 1)
+	is.Equal(len(e.ChildEvents), 2)
+	is.Equal(e.ChildEvents[1].ParentEventID, 1)
+	is.Equal(len(e.Duplicates), 2)
+	is.Equal(e.Duplicates[1].ID, 7)
+	is.Equal(e.Duplicates[1].SeriesUUID, "abc")
+}
+
+func TestCalendarSeries(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	defer swapCanvas(&Canvas{client: cli})()
+
+	mux.HandleFunc("/api/v1/calendar_events/4", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.Method {
+		case "PUT":
+			is.Equal(q.Get("which"), FollowingEvents)
+			is.Equal(q.Get("calendar_event[rrule]"), "FREQ=WEEKLY;INTERVAL=2;COUNT=3")
+			w.Write([]byte(`[{"id":4,"series_uuid":"abc","series_head":false},{"id":7,"series_uuid":"abc"}]`))
+		case "DELETE":
+			is.Equal(q.Get("which"), OneEvent)
+			w.Write([]byte(`{"id":4,"workflow_state":"deleted"}`))
+		}
+	})
+
+	events, err := UpdateCalendarSeries(&CalendarEvent{
+		ID:    4,
+		RRule: "FREQ=WEEKLY;INTERVAL=2;COUNT=3",
+	}, FollowingEvents)
+	is.NoErr(err)
+	is.Equal(len(events), 2)
+	is.Equal(events[1].ID, 7)
+
+	events, err = DeleteCalendarSeries(&CalendarEvent{ID: 4}, OneEvent)
+	is.NoErr(err)
+	is.Equal(len(events), 1)
+	is.Equal(events[0].WorkflowState, "deleted")
+}
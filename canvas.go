@@ -4,6 +4,7 @@
 package canvas
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,9 +14,6 @@ import (
 	"os"
 	"path"
 	"path/filepath"
-	"time"
-
-	"github.com/harrybrwn/go-querystring/query"
 )
 
 var (
@@ -28,11 +26,13 @@ var (
 	// If you do not want to stop all concurrent goroutines, this
 	// handler should return an non-nil error. If this handler returns
 	// nil then all goroutines will continue if they can.
-	// This function panics by default.
+	// By default the error is returned so channels are closed at the
+	// first error. Use the Iter or List forms of a function to get
+	// the error that stopped the list.
 	ConcurrentErrorHandler func(error) error = defaultErrorHandler
 
 	// DefaultUserAgent is the default user agent used to make requests.
-	DefaultUserAgent = "go-canvas v0.1"
+	DefaultUserAgent = defaultUserAgent
 
 	// DefaultCanvas is the default canvas object
 	ca *Canvas
@@ -62,18 +62,25 @@ func New(token string) *Canvas {
 func WithHost(token, host string) *Canvas {
 	c := http.Client{}
 	authorize(&c, token, host)
-	return &Canvas{&client{Client: c, host: host}}
+	return &Canvas{&client{
+		Client: c,
+		base:   &url.URL{Scheme: "https", Host: host},
+	}}
 }
 
 // Canvas is the main api entry point.
 type Canvas struct {
 	client doer
-	// client *client
 }
 
 // SetHost will set the host for the canvas requestor.
 func (c *Canvas) SetHost(host string) error {
-	auth, ok := c.client.(*client).Transport.(*auth)
+	if cl, ok := baseClient(c.client); ok {
+		base := *cl.base
+		base.Host = host
+		cl.base = &base
+	}
+	auth, ok := transport(c.client)
 	if !ok {
 		return errors.New("could not set canvas host")
 	}
@@ -81,6 +88,20 @@ func (c *Canvas) SetHost(host string) error {
 	return nil
 }
 
+// WithContext returns a copy of the package level canvas object
+// that will send all of its requests using ctx.
+func WithContext(ctx context.Context) *Canvas { return ca.WithContext(ctx) }
+
+// WithContext returns a shallow copy of the canvas object that will
+// send all of its requests using ctx. Any objects that are returned
+// by the new canvas object will also use ctx for their requests, so
+// cancelling ctx will stop any pending requests and paginated lists.
+func (c *Canvas) WithContext(ctx context.Context) *Canvas {
+	cp := *c
+	cp.client = withContext(c.client, ctx)
+	return &cp
+}
+
 // Courses lists all of the courses associated
 // with that canvas object.
 //
@@ -92,34 +113,14 @@ func Courses(opts ...Option) ([]*Course, error) { return ca.Courses(opts...) }
 //
 // https://canvas.instructure.com/doc/api/courses.html#method.courses.index
 func (c *Canvas) Courses(opts ...Option) ([]*Course, error) {
-	return getCourses(c.client, "/courses", optEnc(opts))
+	return getCourses(c.client, "/courses", opts)
 }
 
-func getCourses(c doer, path string, opts optEnc) (crs []*Course, err error) {
-	ch := make(chan *Course)
-	pager := newPaginatedList(
-		c, path, func(r io.Reader) error {
-			list := make([]*Course, 0)
-			if err := json.NewDecoder(r).Decode(&list); err != nil {
-				return err
-			}
-			for _, course := range list {
-				course.client = c
-				course.errorHandler = ConcurrentErrorHandler
-				ch <- course
-			}
-			return nil
-		}, opts,
-	)
-	errs := pager.start()
-	for {
-		select {
-		case course := <-ch:
-			crs = append(crs, course)
-		case err := <-errs:
-			return crs, err
-		}
-	}
+func getCourses(d doer, path string, opts []Option) ([]*Course, error) {
+	it := newCourseIterator(d, path, opts)
+	crs := make([]*Course, 0)
+	err := collect(it.iterator, &crs)
+	return crs, err
 }
 
 // CoursesChan returns a channel of courses
@@ -129,22 +130,51 @@ func CoursesChan(opts ...Option) <-chan *Course {
 
 // CoursesChan returns a channel of courses
 func (c *Canvas) CoursesChan(opts ...Option) <-chan *Course {
-	ch := make(courseChan)
-	pager := newPaginatedList(
-		c.client, "/courses", func(r io.Reader) error {
-			list := make([]*Course, 0)
-			if err := json.NewDecoder(r).Decode(&list); err != nil {
+	it := c.CoursesIter(opts...)
+	it.handler = ConcurrentErrorHandler
+	ch := make(chan *Course)
+	stream(it.iterator, ch)
+	return ch
+}
+
+// CoursesIter returns an iterator over the courses
+// associated with the package level canvas object.
+func CoursesIter(opts ...Option) *CourseIterator {
+	return ca.CoursesIter(opts...)
+}
+
+// CoursesIter returns an iterator over the courses
+// associated with that canvas object.
+//
+// https://canvas.instructure.com/doc/api/courses.html#method.courses.index
+func (c *Canvas) CoursesIter(opts ...Option) *CourseIterator {
+	return newCourseIterator(c.client, "/courses", opts)
+}
+
+// CourseIterator iterates over a paginated list of courses.
+type CourseIterator struct{ *iterator }
+
+// Value returns the current course.
+func (it *CourseIterator) Value() *Course {
+	c, _ := it.cur.(*Course)
+	return c
+}
+
+func newCourseIterator(d doer, path string, opts []Option) *CourseIterator {
+	return &CourseIterator{newIterator(d, path, opts, func(r io.Reader, emit emitFunc) error {
+		list := make([]*Course, 0)
+		if err := json.NewDecoder(r).Decode(&list); err != nil {
+			return err
+		}
+		for _, course := range list {
+			course.client = d
+			course.errorHandler = ConcurrentErrorHandler
+			if err := emit(course); err != nil {
 				return err
 			}
-			for _, course := range list {
-				course.client = c.client
-				course.errorHandler = ConcurrentErrorHandler
-				ch <- course
-			}
-			return nil
-		}, opts)
-	go handleErrs(pager.start(), ch, ConcurrentErrorHandler)
-	return ch
+		}
+		return nil
+	})}
 }
 
 // GetCourse will get a course given a course id.
@@ -230,9 +260,18 @@ func GetFile(id int, opts ...Option) (*File, error) { return ca.GetFile(id, opts
 // Files will return a channel of all the default user's files.
 // https://canvas.instructure.com/doc/api/files.html#method.files.api_index
 func (c *Canvas) Files(opts ...Option) <-chan *File {
-	return filesChannel(c.client, "/users/self/files", ConcurrentErrorHandler, opts, nil)
+	return filesChannel(c.FilesIter(opts...), ConcurrentErrorHandler)
+}
+
+// FilesIter returns an iterator over the current user's files.
+// https://canvas.instructure.com/doc/api/files.html#method.files.api_index
+func (c *Canvas) FilesIter(opts ...Option) *FileIterator {
+	return newFileIterator(c.client, "/users/self/files", nil, opts)
 }
 
+// FilesIter returns an iterator over the current user's files.
+func FilesIter(opts ...Option) *FileIterator { return ca.FilesIter(opts...) }
+
 // ListFiles will return a slice of the current user's files.
 func (c *Canvas) ListFiles(opts ...Option) ([]*File, error) {
 	return listFiles(c.client, "/users/self/files", nil, opts)
@@ -247,14 +286,17 @@ func Files(opts ...Option) <-chan *File { return ca.Files(opts...) }
 
 // Folders returns a channel of folders for the current user.
 func (c *Canvas) Folders(opts ...Option) <-chan *Folder {
-	return foldersChannel(
-		c.client,
-		"/users/self/folders",
-		ConcurrentErrorHandler,
-		opts, nil,
-	)
+	return foldersChannel(c.FoldersIter(opts...), ConcurrentErrorHandler)
+}
+
+// FoldersIter returns an iterator over the current user's folders.
+func (c *Canvas) FoldersIter(opts ...Option) *FolderIterator {
+	return newFolderIterator(c.client, "/users/self/folders", nil, opts)
 }
 
+// FoldersIter returns an iterator over the current user's folders.
+func FoldersIter(opts ...Option) *FolderIterator { return ca.FoldersIter(opts...) }
+
 // Folders returns a channel of folders for the current user.
 func Folders(opts ...Option) <-chan *Folder { return ca.Folders(opts...) }
 
@@ -362,9 +404,21 @@ type Account struct {
 	cli doer
 }
 
+// WithContext returns a copy of the account that uses ctx.
+func (a *Account) WithContext(ctx context.Context) *Account {
+	cp := *a
+	cp.cli = withContext(a.cli, ctx)
+	return &cp
+}
+
 // Courses returns the account's list of courses
 func (a *Account) Courses(opts ...Option) (courses []*Course, err error) {
-	return getCourses(a.cli, fmt.Sprintf("/accounts/%d/courses", a.ID), optEnc(opts))
+	return getCourses(a.cli, fmt.Sprintf("/accounts/%d/courses", a.ID), opts)
+}
+
+// CoursesIter returns an iterator over the account's courses.
+func (a *Account) CoursesIter(opts ...Option) *CourseIterator {
+	return newCourseIterator(a.cli, fmt.Sprintf("/accounts/%d/courses", a.ID), opts)
 }
 
 // SearchAccounts will search for canvas accounts.
@@ -386,21 +440,14 @@ func (c *Canvas) Announcements(
 	contextCodes []string,
 	opts ...Option,
 ) (arr []*DiscussionTopic, err error) {
-	opts = append(opts, Opt("context_codes[]", contextCodes))
-	ch := make(chan *DiscussionTopic)
-	pager := newPaginatedList(
-		c.client, "/announcements",
-		sendDiscussionTopicFunc(ch), opts)
-	arr = make([]*DiscussionTopic, 0)
-	errs := pager.start()
-	for {
-		select {
-		case an := <-ch:
-			arr = append(arr, an)
-		case err := <-errs:
-			return arr, err
-		}
-	}
+	return collectDiscussionTopics(c.AnnouncementsIter(contextCodes, opts...))
+}
+
+// AnnouncementsIter returns an iterator over the announcements.
+// https://canvas.instructure.com/doc/api/all_resources.html#method.announcements_api.index
+func (c *Canvas) AnnouncementsIter(contextCodes []string, opts ...Option) *DiscussionTopicIterator {
+	opts = append(opts, ArrayOpt("context_codes", contextCodes...))
+	return newDiscussionTopicIterator(c.client, "/announcements", opts)
 }
 
 // Announcements will get the announcements
@@ -411,229 +458,6 @@ func Announcements(
 	return ca.Announcements(contextCodes, opts...)
 }
 
-// DiscussionTopic is a discussion topic
-type DiscussionTopic struct {
-	ID                      int         `json:"id"`
-	Title                   string      `json:"title"`
-	Message                 string      `json:"message"`
-	HTMLURL                 string      `json:"html_url"`
-	PostedAt                time.Time   `json:"posted_at"`
-	LastReplyAt             time.Time   `json:"last_reply_at"`
-	RequireInitialPost      bool        `json:"require_initial_post"`
-	UserCanSeePosts         bool        `json:"user_can_see_posts"`
-	DiscussionSubentryCount int         `json:"discussion_subentry_count"`
-	ReadState               string      `json:"read_state"`
-	UnreadCount             int         `json:"unread_count"`
-	Subscribed              bool        `json:"subscribed"`
-	SubscriptionHold        string      `json:"subscription_hold"`
-	AssignmentID            interface{} `json:"assignment_id"`
-	DelayedPostAt           interface{} `json:"delayed_post_at"`
-	Published               bool        `json:"published"`
-	LockAt                  interface{} `json:"lock_at"`
-	Locked                  bool        `json:"locked"`
-	Pinned                  bool        `json:"pinned"`
-	LockedForUser           bool        `json:"locked_for_user"`
-	LockInfo                interface{} `json:"lock_info"`
-	LockExplanation         string      `json:"lock_explanation"`
-	UserName                string      `json:"user_name"`
-	TopicChildren           []int       `json:"topic_children"`
-	GroupTopicChildren      []struct {
-		ID      int `json:"id"`
-		GroupID int `json:"group_id"`
-	} `json:"group_topic_children"`
-	RootTopicID     interface{} `json:"root_topic_id"`
-	PodcastURL      string      `json:"podcast_url"`
-	DiscussionType  string      `json:"discussion_type"`
-	GroupCategoryID interface{} `json:"group_category_id"`
-	Attachments     interface{} `json:"attachments"`
-	Permissions     struct {
-		Attach bool `json:"attach"`
-	} `json:"permissions"`
-	AllowRating        bool `json:"allow_rating"`
-	OnlyGradersCanRate bool `json:"only_graders_can_rate"`
-	SortByRating       bool `json:"sort_by_rating"`
-}
-
-// CalendarEvents makes a call to get calendar events.
-func (c *Canvas) CalendarEvents(opts ...Option) (cal []*CalendarEvent, err error) {
-	ch := make(chan *CalendarEvent)
-	pager := newPaginatedList(c.client, "/calendar_events", func(r io.Reader) error {
-		evs := make([]*CalendarEvent, 0)
-		if err := json.NewDecoder(r).Decode(&evs); err != nil {
-			return err
-		}
-		for _, e := range evs {
-			ch <- e
-		}
-		return nil
-	}, opts)
-	errs := pager.start()
-	events := make([]*CalendarEvent, 0)
-	for {
-		select {
-		case event := <-ch:
-			events = append(events, event)
-		case err := <-errs:
-			return events, err
-		}
-	}
-}
-
-// CalendarEvents makes a call to get calendar events.
-func CalendarEvents(opts ...Option) ([]*CalendarEvent, error) {
-	return ca.CalendarEvents(opts...)
-}
-
-type calendarEventOptions struct {
-	CalendarEvent `url:"calendar_event"`
-}
-
-// CreateCalendarEvent will send a calendar event to canvas to be created.
-// https://canvas.instructure.com/doc/api/all_resources.html#method.calendar_events_api.create
-func (c *Canvas) CreateCalendarEvent(event *CalendarEvent) (*CalendarEvent, error) {
-	// TODO: figure out how to send theses fields:
-	// 	- calendar_event[child_event_data][X][start_at]
-	// 	- calendar_event[duplicate][count]
-	// 	- calendar_event[duplicate][interval]
-	// 	- calendar_event[duplicate][frequency]
-	// see https://canvas.instructure.com/doc/api/all_resources.html#method.calendar_events_api.create
-	q, err := query.Values(&calendarEventOptions{*event})
-	if err != nil {
-		return nil, err
-	}
-	resp, err := post(c.client, "/calendar_events", q)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-	cal := &CalendarEvent{}
-	return cal, json.NewDecoder(resp.Body).Decode(cal)
-}
-
-// CreateCalendarEvent will send a calendar event to canvas to be created.
-// https://canvas.instructure.com/doc/api/all_resources.html#method.calendar_events_api.create
-func CreateCalendarEvent(event *CalendarEvent) (*CalendarEvent, error) {
-	return ca.CreateCalendarEvent(event)
-}
-
-// UpdateCalendarEvent will update a calendar event. This operation will change
-// event given as an argument.
-// https://canvas.instructure.com/doc/api/all_resources.html#method.calendar_events_api.update
-func (c *Canvas) UpdateCalendarEvent(event *CalendarEvent) error {
-	q, err := query.Values(&calendarEventOptions{*event})
-	if err != nil {
-		return err
-	}
-	resp, err := put(c.client, fmt.Sprintf("/calendar_events/%d", event.ID), q)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-	return json.NewDecoder(resp.Body).Decode(event)
-}
-
-// UpdateCalendarEvent will update a calendar event. This operation will change
-// event given as an argument.
-// https://canvas.instructure.com/doc/api/all_resources.html#method.calendar_events_api.update
-func UpdateCalendarEvent(event *CalendarEvent) error {
-	return ca.UpdateCalendarEvent(event)
-}
-
-// DeleteCalendarEventByID will delete a calendar event given its ID.
-// This operation returns the calendar event that was deleted.
-func (c *Canvas) DeleteCalendarEventByID(id int, opts ...Option) (*CalendarEvent, error) {
-	resp, err := delete(c.client, fmt.Sprintf("/calendar_events/%d", id), optEnc(opts))
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-	e := &CalendarEvent{}
-	return e, json.NewDecoder(resp.Body).Decode(e)
-}
-
-// DeleteCalendarEventByID will delete a calendar event given its ID.
-// This operation returns the calendar event that was deleted.
-func DeleteCalendarEventByID(id int, opts ...Option) (*CalendarEvent, error) {
-	return ca.DeleteCalendarEventByID(id, opts...)
-}
-
-// DeleteCalendarEvent will delete the calendar event and
-// return the calendar event deleted.
-func (c *Canvas) DeleteCalendarEvent(e *CalendarEvent) (*CalendarEvent, error) {
-	return c.DeleteCalendarEventByID(e.ID)
-}
-
-// DeleteCalendarEvent will delete the calendar event and
-// return the calendar event deleted.
-func DeleteCalendarEvent(e *CalendarEvent) (*CalendarEvent, error) {
-	return ca.DeleteCalendarEventByID(e.ID)
-}
-
-// CalendarEvent is a calendar event
-type CalendarEvent struct {
-	ID                         int         `json:"id" url:"-"`
-	Title                      string      `json:"title" url:"title,omitempty"`
-	ContextCode                string      `json:"context_code" url:"context_code,omitempty"`
-	StartAt                    time.Time   `json:"start_at" url:"start_at,omitempty"`
-	EndAt                      time.Time   `json:"end_at" url:"end_at,omitempty"`
-	CreatedAt                  time.Time   `json:"created_at" url:"-"`
-	UpdatedAt                  time.Time   `json:"updated_at" url:"-"`
-	Description                string      `json:"description" url:"description,omitempty"`
-	LocationName               string      `json:"location_name" url:"location_name,omitempty"`
-	LocationAddress            string      `json:"location_address" url:"location_address,omitempty"`
-	EffectiveContextCode       interface{} `json:"effective_context_code" url:"effective_context_code,omitempty"`
-	AllDay                     bool        `json:"all_day" url:"all_day,omitempty"`
-	AllContextCodes            string      `json:"all_context_codes" url:"-"`
-	WorkflowState              string      `json:"workflow_state" url:"-"`
-	Hidden                     bool        `json:"hidden" url:"-"`
-	ParentEventID              interface{} `json:"parent_event_id" url:"-"`
-	ChildEventsCount           int         `json:"child_events_count" url:"-"`
-	ChildEvents                interface{} `json:"child_events" url:"-"`
-	URL                        string      `json:"url" url:"-"`
-	HTMLURL                    string      `json:"html_url" url:"-"`
-	AllDayDate                 string      `json:"all_day_date" url:"-"`
-	AppointmentGroupID         interface{} `json:"appointment_group_id" url:"-"`
-	AppointmentGroupURL        string      `json:"appointment_group_url" url:"-"`
-	OwnReservation             bool        `json:"own_reservation" url:"-"`
-	ReserveURL                 string      `json:"reserve_url" url:"-"`
-	Reserved                   bool        `json:"reserved" url:"-"`
-	ParticipantType            string      `json:"participant_type" url:"-"`
-	ParticipantsPerAppointment interface{} `json:"participants_per_appointment" url:"-"`
-	AvailableSlots             interface{} `json:"available_slots" url:"-"`
-	User                       *User       `json:"user" url:"-"`
-	Group                      interface{} `json:"group" url:"-"`
-}
-
-// Conversations returns a list of conversations
-func (c *Canvas) Conversations(opts ...Option) (conversations []Conversation, err error) {
-	return conversations, getjson(c.client, &conversations, optEnc(opts), "/conversations")
-}
-
-// Conversations returns a list of conversations
-func Conversations(opts ...Option) ([]Conversation, error) {
-	return ca.Conversations(opts...)
-}
-
-// Conversation is a conversation.
-type Conversation struct {
-	ID               int         `json:"id"`
-	Subject          string      `json:"subject"`
-	WorkflowState    string      `json:"workflow_state"`
-	LastMessage      string      `json:"last_message"`
-	StartAt          time.Time   `json:"start_at"`
-	MessageCount     int         `json:"message_count"`
-	Subscribed       bool        `json:"subscribed"`
-	Private          bool        `json:"private"`
-	Starred          bool        `json:"starred"`
-	Properties       interface{} `json:"properties"`
-	Audience         interface{} `json:"audience"`
-	AudienceContexts interface{} `json:"audience_contexts"`
-	AvatarURL        string      `json:"avatar_url"`
-	Participants     interface{} `json:"participants"`
-	Visible          bool        `json:"visible"`
-	ContextName      string      `json:"context_name"`
-}
-
 // Bookmarks will get the current user's bookmarks.
 func (c *Canvas) Bookmarks(opts ...Option) (b []Bookmark, err error) {
 	return b, getjson(c.client, &b, optEnc(opts), "/users/self/bookmarks")
@@ -709,16 +533,3 @@ func getAccounts(d doer, path string, opts []Option) (accts []Account, err error
 	}
 	return
 }
-
-func sendDiscussionTopicFunc(ch chan *DiscussionTopic) sendFunc {
-	return func(r io.Reader) error {
-		discs := make([]*DiscussionTopic, 0)
-		if err := json.NewDecoder(r).Decode(&discs); err != nil {
-			return err
-		}
-		for _, d := range discs {
-			ch <- d
-		}
-		return nil
-	}
-}
@@ -1,7 +1,9 @@
 package canvas
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -104,28 +106,32 @@ func TestAssignments(t *testing.T) {
 }
 
 func TestSetHost(t *testing.T) {
-	client, ok := ca.client.(*client)
+	client, ok := baseClient(ca.client)
 	if !ok {
-		t.Error("could not get the client")
+		t.Fatal("could not get the client")
 	}
-	trans := client.Transport
-	auth, ok := trans.(*auth)
+	auth, ok := transport(client)
 	if !ok {
-		t.Fatalf("could not set a host for this transport: %T", trans)
+		t.Fatalf("could not set a host for this transport: %T", client.Transport)
 	}
-	host := auth.host
+	base, host := client.base, auth.host
+	defer func() { client.base, auth.host = base, host }()
 	if err := SetHost("test.host"); err != nil {
 		t.Error(err)
 	}
-	if auth.host != "test.host" {
+	if client.base.Host != "test.host" || auth.host != "test.host" {
 		t.Error("did not set correct host")
 	}
-	client.Transport = http.DefaultTransport
-	if err := SetHost("test1.host"); err == nil {
-		t.Errorf("expected an error for setting host on %T", client.Transport)
+	if client.base.Scheme != "https" {
+		t.Error("should not have changed the scheme")
+	}
+	if base.Host == "test.host" {
+		t.Error("should not modify the old base url")
+	}
+	c := &Canvas{client: &http.Client{Transport: http.DefaultTransport}}
+	if err := c.SetHost("test1.host"); err == nil {
+		t.Errorf("expected an error for setting host on %T", http.DefaultTransport)
 	}
-	client.Transport = auth
-	auth.host = host
 }
 
 func TestAnnouncements(t *testing.T) {
@@ -211,6 +217,50 @@ func TestUpdateCalendarEvent(t *testing.T) {
 	DeleteCalendarEventByID(event.ID)
 }
 
+func TestWithContext(t *testing.T) {
+	cli, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/users/self", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "GET")
+		writeTestFile(t, "user.json", w)
+	})
+	mux.HandleFunc("/api/v1/users/self/files", handlePagingatedList(t, 5, "file.json"))
+	c := &Canvas{client: cli}
+	ctx, cancel := context.WithCancel(context.Background())
+	canv := c.WithContext(ctx)
+	u, err := canv.CurrentUser()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contextOf(u.client) != ctx {
+		t.Error("user should have been given the canvas context")
+	}
+	files, err := canv.ListFiles()
+	if err != nil {
+		t.Error(err)
+	}
+	if len(files) != 5 {
+		t.Errorf("expected 5 files; got %d", len(files))
+	}
+
+	cancel()
+	if _, err = u.Settings(); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a context.Canceled error; got %v", err)
+	}
+	if _, err = canv.ListFiles(); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a context.Canceled error; got %v", err)
+	}
+	if _, err = canv.Courses(); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a context.Canceled error; got %v", err)
+	}
+	for range canv.Files() {
+		t.Error("should not get files with a cancelled context")
+	}
+	if _, err = c.CurrentUser(); err != nil {
+		t.Error("original canvas object should not be cancelled:", err)
+	}
+}
+
 func TestUser_Err(t *testing.T) {
 	is := is.New(t)
 	u, err := testUser()
@@ -463,13 +513,6 @@ func TestLinks(t *testing.T) {
 		{"Link": {`<https://canvas.instructure.com/api/v1/courses/000/files?page=1&per_page=10>; rel="current",<https://canvas.instructure.com/api/v1/courses/000/files?page=2&per_page=10>; rel="next",<https://canvas.instructure.com/api/v1/courses/000/files?page=1&per_page=10>; rel="first",<https://canvas.instructure.com/api/v1/courses/000/files?page=45&per_page=10>; rel="last"`}},
 	}
 	for _, header := range headers {
-		n, err := findlastpage(header)
-		if err != nil {
-			t.Error(err)
-		}
-		if n != 45 {
-			t.Error("wrong page number")
-		}
 		links, err := newLinkedResource(header)
 		if err != nil {
 			t.Error(err)
@@ -478,16 +521,84 @@ func TestLinks(t *testing.T) {
 			t.Error("wrong page number")
 		}
 	}
-	n, err := findlastpage(http.Header{})
-	if err == nil {
-		t.Error("expected an error")
+	links, err := newLinkedResource(http.Header{
+		"Link": {`<https://canvas.instructure.com/api/v1/users/2/page_views?page=bookmark:WyIyMDIwLTA0LTE1Il0&per_page=10>; rel="current",<https://canvas.instructure.com/api/v1/users/2/page_views?page=bookmark:WyIyMDIwLTA0LTEzIl0&per_page=10>; rel="next",<https://canvas.instructure.com/api/v1/users/2/page_views?page=first&per_page=10>; rel="first"`},
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	if links.First == nil || links.First.page != 0 {
+		t.Error("expected the first link to be treated as a bookmark")
+	}
+	if links.Last != nil {
+		t.Error("should not have a last link")
 	}
-	if n != -1 {
-		t.Error("n should be -1")
+	if links.Next == nil || links.Next.page != 0 {
+		t.Error("expected a bookmark for the next link")
+	}
+	links, err = newLinkedResource(http.Header{})
+	if err != nil {
+		t.Error(err)
+	}
+	if links.Next != nil || links.Last != nil {
+		t.Error("no links should be found in an empty header")
 	}
 }
 
 func TestErrors(t *testing.T) {
+	is := is.New(t)
+	newResp := func(status int, body string, h http.Header) *http.Response {
+		if h == nil {
+			h = http.Header{}
+		}
+		return &http.Response{
+			StatusCode: status,
+			Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+			Header:     h,
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+			Request:    &http.Request{Method: "GET", URL: &url.URL{Path: "/api/v1/courses/1"}},
+		}
+	}
+	e := newAPIError(newResp(404, `{"errors":[{"message":"The specified resource does not exist."}]}`, http.Header{
+		"X-Request-Context-Id": {"abc-123"},
+	}))
+	is.Equal(e.Error(), "GET /api/v1/courses/1: 404 Not Found: The specified resource does not exist.")
+	is.Equal(e.RequestID, "abc-123")
+	is.True(errors.Is(e, ErrNotFound))
+	is.True(!errors.Is(e, ErrUnauthorized))
+
+	e = newAPIError(newResp(401, `{"status":"unauthenticated","errors":[{"message":"Invalid access token."}]}`, nil))
+	is.True(errors.Is(e, ErrUnauthorized))
+	is.Equal(e.Errors[0].Message, "Invalid access token.")
+
+	e = newAPIError(newResp(403, `{"status":"unauthorized","errors":[{"message":"user not authorized to perform that action"}]}`, nil))
+	is.True(errors.Is(e, ErrForbidden))
+	is.True(!IsRateLimit(e))
+
+	e = newAPIError(newResp(403, "403 Forbidden (Rate Limit Exceeded)\n", http.Header{"Retry-After": {"3"}}))
+	is.True(IsRateLimit(e))
+	is.True(errors.Is(e, ErrRateLimitExceeded))
+	is.True(!errors.Is(e, ErrForbidden))
+	is.Equal(e.Message, "403 Forbidden (Rate Limit Exceeded)")
+	is.Equal(e.RetryAfter, 3*time.Second)
+
+	e = newAPIError(newResp(422, `{"errors":{"name":[{"attribute":"name","type":"blank","message":"can't be blank"}],"end_date":"no"}}`, nil))
+	is.True(errors.Is(e, ErrValidation))
+	is.Equal(e.FieldErrors["name"][0].Type, "blank")
+	is.Equal(e.FieldErrors["end_date"][0].Message, "no")
+	is.Equal(e.Error(), "GET /api/v1/courses/1: 422 Unprocessable Entity: end_date: no, name: can't be blank")
+
+	e = newAPIError(newResp(500, `{"errors":[{"message":"An error occurred.","error_code":"internal_server_error"}],"error_report_id":42}`, nil))
+	is.Equal(e.ErrorReportID, 42)
+	is.Equal(e.Errors[0].ErrorCode, "internal_server_error")
+
+	var apiErr *APIError
+	is.True(errors.As(fmt.Errorf("wrapped: %w", e), &apiErr))
+	is.Equal(apiErr.StatusCode, 500)
+	is.True(!IsRateLimit(nil))
+}
+
+func TestErrors_Deprecated(t *testing.T) {
 	is := is.New(t)
 	e := &AuthError{
 		Status: "test",
@@ -510,15 +621,37 @@ func TestErrors(t *testing.T) {
 	is.True(!IsRateLimit(nil))
 	err = &Error{SentryID: "testid", Err: "this is an error"}
 	is.Equal(err.Error(), "error status: this is an error; sentryId: testid")
+
+	// api errors can still be matched with the old types
+	newResp := func(status int, body string) *http.Response {
+		return &http.Response{
+			StatusCode: status,
+			Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+		}
+	}
+	var authErr *AuthError
+	apiErr := newAPIError(newResp(401, `{"errors":[{"message":"Invalid access token."}]}`))
+	is.True(errors.As(apiErr, &authErr))
+	is.Equal(authErr.Error(), "401 Unauthorized: Invalid access token.")
+	is.True(!errors.As(apiErr, &err))
+
+	apiErr = newAPIError(newResp(400, `{"errors":{"end_date":"no"}}`))
+	is.True(errors.As(fmt.Errorf("wrapped: %w", apiErr), &err))
+	is.Equal(err.Error(), "end_date: no")
+	is.True(!errors.As(apiErr, &authErr))
 }
 
 func TestRateLimitErr(t *testing.T) {
 	cli, mux, server := testServer()
 	defer server.Close()
 	defer swapCanvas(&Canvas{client: cli})()
+	testRetrier(t, cli)
 	mux.HandleFunc("/api/v1/accounts/self", func(w http.ResponseWriter, r *http.Request) {
 		assertMethod(t, r, "GET")
 		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("403 Forbidden (Rate Limit Exceeded)"))
 	})
 	mux.HandleFunc("/api/v1/accounts", func(w http.ResponseWriter, r *http.Request) {
 		assertMethod(t, r, "GET")
@@ -537,14 +670,17 @@ func TestRateLimitErr(t *testing.T) {
 		t.Error("expected rate limit error")
 	}
 	_, err = Accounts()
-	if !IsRateLimit(err) {
-		t.Error("expected rate limit error")
+	if IsRateLimit(err) {
+		t.Error("forbidden responses are not always rate limit errors")
+	}
+	if !errors.Is(err, ErrForbidden) {
+		t.Error("expected a forbidden error")
 	}
 	folder := &Folder{ID: 123}
 	file := &File{client: cli, ID: 54321}
 	err = file.Copy(folder)
-	if err == nil {
-		t.Error("expected an error")
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("expected a validation error; got %v", err)
 	}
 }
 
@@ -556,6 +692,7 @@ func TestUtils(t *testing.T) {
 		{"User", "users"},
 		{"GroupCategory", "group_categories"},
 		{"Account", "accounts"},
+		{"Group", "groups"},
 	}
 	for _, test := range tests {
 		if path := pathFromContextType(test.in); path != test.out {
@@ -585,6 +722,10 @@ func TestOptions(t *testing.T) {
 	if !strings.Contains(q, "key=value") {
 		t.Error("should have the key-value pair")
 	}
+	is.Equal(optEnc{PageWorkers(2), Opt("a", 1)}.Encode(), "a=1")
+	p := params{}
+	p.Add([]Option{PageWorkers(2)})
+	is.Equal(len(p), 0)
 	prefed := toPrefixedOpts("prefix", opts)
 	for _, o := range prefed {
 		if !strings.Contains(o.Name(), "prefix") {
@@ -0,0 +1,264 @@
+package canvas
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+)
+
+const (
+	defaultBaseURL   = "https://canvas.instructure.com"
+	defaultUserAgent = "go-canvas v0.1"
+)
+
+// NewClient will create a new Canvas object that uses token to
+// authenticate requests. NewClient does not use any of the package
+// level defaults, the client is only configured by the options given.
+func NewClient(token string, opts ...ClientOption) (*Canvas, error) {
+	base, _ := url.Parse(defaultBaseURL)
+	conf := clientConfig{
+		base:        base,
+		userAgent:   defaultUserAgent,
+		perPage:     defaultPerPage,
+		pageWorkers: defaultPageWorkers,
+		maxAttempts: defaultMaxAttempts,
+	}
+	for _, opt := range opts {
+		if err := opt(&conf); err != nil {
+			return nil, err
+		}
+	}
+	return &Canvas{client: newClient(token, &conf)}, nil
+}
+
+// ClientOption is an option used to configure
+// a Canvas object created by NewClient.
+type ClientOption func(*clientConfig) error
+
+// WithHTTPClient will make the new client send requests using a copy
+// of an http.Client. The copy's transport will be wrapped so that requests
+// are authenticated.
+func WithHTTPClient(c *http.Client) ClientOption {
+	return func(conf *clientConfig) error {
+		if c == nil {
+			return errors.New("nil http client")
+		}
+		conf.http = *c
+		return nil
+	}
+}
+
+// WithTransport sets the http.RoundTripper that will be used
+// to send requests.
+func WithTransport(rt http.RoundTripper) ClientOption {
+	return func(conf *clientConfig) error {
+		conf.http.Transport = rt
+		return nil
+	}
+}
+
+// WithBaseURL sets the url that all api requests are sent to. The
+// url should include a scheme and can include a port and path prefix
+// (ex. "https://canvas.school.edu" or "http://localhost:3000").
+func WithBaseURL(baseurl string) ClientOption {
+	return func(conf *clientConfig) error {
+		u, err := url.Parse(baseurl)
+		if err != nil {
+			return err
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return errors.New("base url needs a scheme and a host")
+		}
+		conf.base = u
+		return nil
+	}
+}
+
+// WithUserAgent sets the user agent sent with every request.
+func WithUserAgent(agent string) ClientOption {
+	return func(conf *clientConfig) error {
+		conf.userAgent = agent
+		return nil
+	}
+}
+
+// WithPerPage sets the default number of items requested
+// for each page of a paginated list.
+func WithPerPage(n int) ClientOption {
+	return func(conf *clientConfig) error {
+		if n < 1 {
+			return errors.New("per page must be positive")
+		}
+		conf.perPage = n
+		return nil
+	}
+}
+
+// WithTimeout sets the time limit for each request.
+func WithTimeout(d time.Duration) ClientOption {
+	return func(conf *clientConfig) error {
+		conf.http.Timeout = d
+		return nil
+	}
+}
+
+// WithConcurrency limits the number of requests that the client will
+// have waiting on a response at the same time. Response bodies are not
+// counted so that requests can be made while reading a paginated list.
+func WithConcurrency(n int) ClientOption {
+	return func(conf *clientConfig) error {
+		if n < 1 {
+			return errors.New("concurrency limit must be positive")
+		}
+		conf.concurrency = n
+		return nil
+	}
+}
+
+// WithPageWorkers sets the number of pages that will be
+// requested at the same time when getting a paginated list.
+func WithPageWorkers(n int) ClientOption {
+	return func(conf *clientConfig) error {
+		if n < 1 {
+			return errors.New("page workers must be positive")
+		}
+		conf.pageWorkers = n
+		return nil
+	}
+}
+
+// WithMaxAttempts sets the maximum number of times a request is sent
+// when it is being throttled or failing with a server error.
+func WithMaxAttempts(n int) ClientOption {
+	return func(conf *clientConfig) error {
+		if n < 1 {
+			return errors.New("max attempts must be positive")
+		}
+		conf.maxAttempts = n
+		return nil
+	}
+}
+
+// WithTokenSource will authenticate requests using tokens from a
+// TokenSource. The token passed to NewClient is ignored.
+func WithTokenSource(src TokenSource) ClientOption {
+	return func(conf *clientConfig) error {
+		if src == nil {
+			return errors.New("nil token source")
+		}
+		conf.tokens = src
+		return nil
+	}
+}
+
+type clientConfig struct {
+	http        http.Client
+	tokens      TokenSource
+	base        *url.URL
+	userAgent   string
+	perPage     int
+	concurrency int
+	pageWorkers int
+	maxAttempts int
+}
+
+func newClient(token string, conf *clientConfig) *client {
+	rt := conf.http.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	retry := newRetrier(rt)
+	retry.maxAttempts = conf.maxAttempts
+	c := &client{
+		Client:  conf.http,
+		base:    conf.base,
+		perPage: conf.perPage,
+		workers: conf.pageWorkers,
+	}
+	c.Transport = &auth{
+		rt:        retry,
+		token:     token,
+		host:      conf.base.Host,
+		userAgent: conf.userAgent,
+		src:       conf.tokens,
+	}
+	if conf.concurrency > 0 {
+		c.sem = make(chan struct{}, conf.concurrency)
+	}
+	return c
+}
+
+type client struct {
+	http.Client
+	base    *url.URL
+	perPage int
+	workers int
+	sem     chan struct{}
+}
+
+func (c *client) Do(r *http.Request) (*http.Response, error) {
+	if r.URL.Host == "" {
+		r.Host = c.base.Host
+		r.URL.Host = c.base.Host
+		r.URL.Scheme = c.base.Scheme
+		if c.base.Path != "" {
+			r.URL.Path = path.Join(c.base.Path, r.URL.Path)
+		}
+	}
+	if c.sem != nil {
+		select {
+		case c.sem <- struct{}{}:
+		case <-r.Context().Done():
+			return nil, r.Context().Err()
+		}
+		// The slot is released once the headers have arrived. Holding it
+		// until the body is closed would deadlock any request made while
+		// reading a page of a paginated list.
+		defer func() { <-c.sem }()
+	}
+	return c.Client.Do(r)
+}
+
+// baseClient will find the *client that a doer is wrapping.
+func baseClient(d doer) (*client, bool) {
+	if cd, ok := d.(*ctxDoer); ok {
+		d = cd.doer
+	}
+	c, ok := d.(*client)
+	return c, ok
+}
+
+// downloadClient returns the http client used to download files. It uses
+// the client's timeout and transport but does not add the auth token
+// because file urls are signed and may redirect to another host.
+func downloadClient(d doer) *http.Client {
+	c, ok := baseClient(d)
+	if !ok {
+		return http.DefaultClient
+	}
+	hc := c.Client
+	if a, ok := hc.Transport.(*auth); ok {
+		hc.Transport = a.rt
+	}
+	return &hc
+}
+
+// perPage returns the number of items that a
+// doer should ask for in each page of a list.
+func perPage(d doer) int {
+	if c, ok := baseClient(d); ok && c.perPage > 0 {
+		return c.perPage
+	}
+	return defaultPerPage
+}
+
+// pageWorkers returns the number of pages that a doer
+// should request at the same time.
+func pageWorkers(d doer) int {
+	if c, ok := baseClient(d); ok && c.workers > 0 {
+		return c.workers
+	}
+	return defaultPageWorkers
+}
@@ -0,0 +1,198 @@
+package canvas
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewClient(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	mux.HandleFunc("/prefix/api/v1/users/self", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Error("wrong authorization header:", r.Header.Get("Authorization"))
+		}
+		if r.Header.Get("User-Agent") != "test-agent" {
+			t.Error("wrong user agent:", r.Header.Get("User-Agent"))
+		}
+		writeTestFile(t, "user.json", w)
+	})
+	mux.HandleFunc("/prefix/api/v1/users/self/files", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("per_page") != "42" {
+			t.Error("wrong per_page:", r.URL.Query().Get("per_page"))
+		}
+		filesHandlerFunc(t, 3)(w, r)
+	})
+
+	var used bool
+	c, err := NewClient(
+		"test-token",
+		WithBaseURL(server.URL+"/prefix"),
+		WithHTTPClient(&http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			used = true
+			return http.DefaultTransport.RoundTrip(r)
+		})}),
+		WithUserAgent("test-agent"),
+		WithPerPage(42),
+		WithTimeout(time.Second),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := c.CurrentUser()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.ID != 2 {
+		t.Error("got the wrong user")
+	}
+	if !used {
+		t.Error("did not use the http client given")
+	}
+	files, err := c.ListFiles()
+	if err != nil {
+		t.Error(err)
+	}
+	if len(files) != 3 {
+		t.Errorf("expected 3 files; got %d", len(files))
+	}
+	cl, _ := baseClient(c.client)
+	if cl.Timeout != time.Second {
+		t.Error("timeout not set")
+	}
+
+	for _, opt := range []ClientOption{
+		WithBaseURL("no-scheme"),
+		WithPerPage(0),
+		WithConcurrency(-1),
+		WithMaxAttempts(0),
+		WithHTTPClient(nil),
+	} {
+		if _, err = NewClient("", opt); err == nil {
+			t.Error("expected an error from a bad option")
+		}
+	}
+}
+
+func TestNewClient_Concurrency(t *testing.T) {
+	var (
+		mu               sync.Mutex
+		active, most     int
+		limit, nrequests = 2, 8
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		active++
+		if active > most {
+			most = active
+		}
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		active--
+		mu.Unlock()
+		writeTestFile(t, "user.json", w)
+	}))
+	defer server.Close()
+	c, err := NewClient("", WithBaseURL(server.URL), WithConcurrency(limit))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	wg.Add(nrequests)
+	for i := 0; i < nrequests; i++ {
+		go func() {
+			defer wg.Done()
+			if _, err := c.CurrentUser(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if most > limit {
+		t.Errorf("expected at most %d concurrent requests; got %d", limit, most)
+	}
+	if !strings.HasPrefix(server.URL, "http://") {
+		t.Error("test server should be using plain http")
+	}
+}
+
+func TestNewClient_ConcurrencyNested(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id":1},{"id":2},{"id":3}]`))
+	})
+	mux.HandleFunc("/api/v1/users/2", func(w http.ResponseWriter, r *http.Request) {
+		writeTestFile(t, "user.json", w)
+	})
+	c, err := NewClient("", WithBaseURL(server.URL), WithConcurrency(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	c = c.WithContext(ctx)
+	it := c.CoursesIter()
+	defer it.Close()
+	var n int
+	for it.Next() {
+		// requests made while reading a page should not
+		// wait on the page's concurrency slot
+		if _, err = c.GetUser(2); err != nil {
+			t.Fatal(err)
+		}
+		n++
+	}
+	if err = it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Errorf("expected 3 courses; got %d", n)
+	}
+}
+
+func TestFile_DownloadClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Error("file downloads should not send the auth token")
+		}
+		w.Write([]byte("file contents"))
+	}))
+	defer server.Close()
+	var used bool
+	c, err := NewClient(
+		"test-token",
+		WithHTTPClient(&http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			used = true
+			return http.DefaultTransport.RoundTrip(r)
+		})}),
+		WithTimeout(time.Second),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hc := downloadClient(c.client); hc.Timeout != time.Second {
+		t.Error("download client should use the client's timeout")
+	}
+	f := &File{URL: server.URL + "/files/1/download", client: c.client}
+	rc, err := f.AsReadCloser()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rc.Close()
+	if !used {
+		t.Error("downloads should use the client's transport")
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
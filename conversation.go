@@ -0,0 +1,402 @@
+package canvas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Conversation scopes that can be used with Opt("scope", ...) when
+// listing conversations.
+const (
+	ConversationUnread   = "unread"
+	ConversationStarred  = "starred"
+	ConversationArchived = "archived"
+	ConversationSent     = "sent"
+)
+
+// ConversationEvent is an event that can be applied to many
+// conversations at once.
+type ConversationEvent string
+
+// Batch conversation events.
+const (
+	ConversationMarkAsRead   ConversationEvent = "mark_as_read"
+	ConversationMarkAsUnread ConversationEvent = "mark_as_unread"
+	ConversationStar         ConversationEvent = "star"
+	ConversationUnstar       ConversationEvent = "unstar"
+	ConversationArchive      ConversationEvent = "archive"
+	ConversationDestroy      ConversationEvent = "destroy"
+)
+
+// Conversation is a conversation.
+//
+// https://canvas.instructure.com/doc/api/conversations.html
+type Conversation struct {
+	ID            int       `json:"id"`
+	Subject       string    `json:"subject"`
+	WorkflowState string    `json:"workflow_state"`
+	LastMessage   string    `json:"last_message"`
+	LastMessageAt time.Time `json:"last_message_at"`
+	StartAt       time.Time `json:"start_at"`
+	MessageCount  int       `json:"message_count"`
+	Subscribed    bool      `json:"subscribed"`
+	Private       bool      `json:"private"`
+	Starred       bool      `json:"starred"`
+	// Properties can hold "last_author", "attachments" or "media_objects".
+	Properties []string `json:"properties"`
+	// Audience is the ids of the users in the conversation other
+	// than the current user.
+	Audience         []int                     `json:"audience"`
+	AudienceContexts AudienceContexts          `json:"audience_contexts"`
+	AvatarURL        string                    `json:"avatar_url"`
+	Participants     []ConversationParticipant `json:"participants"`
+	Visible          bool                      `json:"visible"`
+	ContextName      string                    `json:"context_name"`
+	ContextCode      string                    `json:"context_code"`
+
+	// Messages is only set when getting a single conversation.
+	Messages []*ConversationMessage `json:"messages"`
+
+	client doer
+}
+
+// AudienceContexts holds the enrollment types that the
+// conversation's audience have in each shared course and group,
+// keyed by the id of the course or group.
+type AudienceContexts struct {
+	Courses map[string][]string `json:"courses"`
+	Groups  map[string][]string `json:"groups"`
+}
+
+// ConversationParticipant is a user in a conversation.
+type ConversationParticipant struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	FullName  string `json:"full_name"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// ConversationMessage is a message in a conversation.
+type ConversationMessage struct {
+	ID                   int                    `json:"id"`
+	CreatedAt            time.Time              `json:"created_at"`
+	Body                 string                 `json:"body"`
+	AuthorID             int                    `json:"author_id"`
+	Generated            bool                   `json:"generated"`
+	MediaComment         *MediaComment          `json:"media_comment"`
+	ForwardedMessages    []*ConversationMessage `json:"forwarded_messages"`
+	Attachments          []*File                `json:"attachments"`
+	ParticipatingUserIDs []int                  `json:"participating_user_ids"`
+}
+
+// MediaComment is an audio or video recording attached to a message.
+type MediaComment struct {
+	MediaID     string `json:"media_id"`
+	MediaType   string `json:"media_type"`
+	DisplayName string `json:"display_name"`
+	ContentType string `json:"content-type"`
+	URL         string `json:"url"`
+}
+
+// Recipient is a user or a context (course, group, section) that
+// a conversation can be sent to.
+//
+// https://canvas.instructure.com/doc/api/search.html#method.search.recipients
+type Recipient struct {
+	// ID can be given to CreateConversation as a recipient. It is a
+	// user id for users or a context code such as "course_1" or
+	// "course_1_students" for contexts.
+	ID        string `json:"-"`
+	Name      string `json:"name"`
+	FullName  string `json:"full_name"`
+	AvatarURL string `json:"avatar_url"`
+	// Type is either "user" or "context".
+	Type          string              `json:"type"`
+	UserCount     int                 `json:"user_count"`
+	CommonCourses map[string][]string `json:"common_courses"`
+	CommonGroups  map[string][]string `json:"common_groups"`
+}
+
+// UnmarshalJSON decodes a recipient. Canvas sends numeric ids for
+// users and string ids for contexts.
+func (r *Recipient) UnmarshalJSON(b []byte) error {
+	type recipient Recipient
+	raw := struct {
+		*recipient
+		ID json.RawMessage `json:"id"`
+	}{recipient: (*recipient)(r)}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	r.ID = strings.Trim(string(raw.ID), `"`)
+	return nil
+}
+
+// Conversations returns a list of the current user's conversations.
+// Use Opt("scope", ConversationUnread) to only get unread conversations
+// and ArrayOpt("filter", "course_1") to filter by context.
+//
+// https://canvas.instructure.com/doc/api/conversations.html#method.conversations.index
+func (c *Canvas) Conversations(opts ...Option) ([]*Conversation, error) {
+	return collectConversations(c.ConversationsIter(opts...))
+}
+
+// ConversationsIter returns an iterator over the current user's conversations.
+//
+// https://canvas.instructure.com/doc/api/conversations.html#method.conversations.index
+func (c *Canvas) ConversationsIter(opts ...Option) *ConversationIterator {
+	return newConversationIterator(c.client, "/conversations", opts)
+}
+
+// Conversations returns a list of the current user's conversations.
+func Conversations(opts ...Option) ([]*Conversation, error) {
+	return ca.Conversations(opts...)
+}
+
+// GetConversation will get a conversation along with all of its messages.
+//
+// https://canvas.instructure.com/doc/api/conversations.html#method.conversations.show
+func (c *Canvas) GetConversation(id int, opts ...Option) (*Conversation, error) {
+	conv := &Conversation{client: c.client}
+	return conv, getjson(c.client, conv, optEnc(opts), "/conversations/%d", id)
+}
+
+// GetConversation will get a conversation along with all of its messages.
+func GetConversation(id int, opts ...Option) (*Conversation, error) {
+	return ca.GetConversation(id, opts...)
+}
+
+// CreateConversation will send a new message to the recipients. Recipients
+// can be user ids or context codes like "course_1", "group_2" or
+// "course_1_teachers". More than one conversation may be returned when
+// sending to many users individually.
+//
+// https://canvas.instructure.com/doc/api/conversations.html#method.conversations.create
+func (c *Canvas) CreateConversation(recipients []string, subject, body string, opts ...Option) ([]*Conversation, error) {
+	return createConversation(c.client, recipients, subject, body, opts)
+}
+
+// CreateConversation will send a new message to the recipients.
+func CreateConversation(recipients []string, subject, body string, opts ...Option) ([]*Conversation, error) {
+	return ca.CreateConversation(recipients, subject, body, opts...)
+}
+
+// UpdateConversations will apply an event to many conversations at once.
+// The returned progress is finished when all of the conversations have
+// been updated.
+//
+// https://canvas.instructure.com/doc/api/conversations.html#method.conversations.batch_update
+func (c *Canvas) UpdateConversations(event ConversationEvent, ids []int) (*Progress, error) {
+	q := params{"event": {string(event)}}
+	for _, id := range ids {
+		q["conversation_ids[]"] = append(q["conversation_ids[]"], strconv.Itoa(id))
+	}
+	resp, err := put(c.client, "/conversations", q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	p := &Progress{client: c.client}
+	return p, json.NewDecoder(resp.Body).Decode(p)
+}
+
+// UpdateConversations will apply an event to many conversations at once.
+func UpdateConversations(event ConversationEvent, ids []int) (*Progress, error) {
+	return ca.UpdateConversations(event, ids)
+}
+
+// UnreadConversations will get the number of unread conversations
+// for the current user.
+//
+// https://canvas.instructure.com/doc/api/conversations.html#method.conversations.unread_count
+func (c *Canvas) UnreadConversations() (int, error) {
+	var res struct {
+		// canvas sends the count as a string
+		Count json.Number `json:"unread_count"`
+	}
+	if err := getjson(c.client, &res, nil, "/conversations/unread_count"); err != nil {
+		return 0, err
+	}
+	n, err := res.Count.Int64()
+	return int(n), err
+}
+
+// UnreadConversations will get the number of unread conversations
+// for the current user.
+func UnreadConversations() (int, error) {
+	return ca.UnreadConversations()
+}
+
+// SearchRecipients will find the users and contexts that
+// the current user can send messages to.
+//
+// https://canvas.instructure.com/doc/api/search.html#method.search.recipients
+func (c *Canvas) SearchRecipients(search string, opts ...Option) (list []*Recipient, err error) {
+	opts = append(opts, Opt("search", search))
+	err = getList(c.client, func(r io.Reader) error {
+		page := make([]*Recipient, 0)
+		if err := json.NewDecoder(r).Decode(&page); err != nil {
+			return err
+		}
+		list = append(list, page...)
+		return nil
+	}, "/search/recipients", opts)
+	return list, err
+}
+
+// SearchRecipients will find the users and contexts that
+// the current user can send messages to.
+func SearchRecipients(search string, opts ...Option) ([]*Recipient, error) {
+	return ca.SearchRecipients(search, opts...)
+}
+
+// WithContext returns a copy of the conversation that uses ctx.
+func (c *Conversation) WithContext(ctx context.Context) *Conversation {
+	cp := *c
+	cp.client = withContext(c.client, ctx)
+	return &cp
+}
+
+// Reply will add a message to the conversation. Use
+// ArrayOpt("attachment_ids", ...) to attach files.
+//
+// https://canvas.instructure.com/doc/api/conversations.html#method.conversations.add_message
+func (c *Conversation) Reply(body string, opts ...Option) (*ConversationMessage, error) {
+	q := params{"body": {body}}
+	q.Add(opts)
+	res := Conversation{}
+	if err := send(c.client, post, c.path()+"/add_message", q, &res); err != nil {
+		return nil, err
+	}
+	if len(res.Messages) == 0 {
+		return nil, fmt.Errorf("no message returned for conversation %d", c.ID)
+	}
+	// messages are ordered newest first
+	c.Messages = append(res.Messages[:1:1], c.Messages...)
+	c.LastMessage, c.LastMessageAt = res.LastMessage, res.LastMessageAt
+	c.MessageCount = res.MessageCount
+	return res.Messages[0], nil
+}
+
+// Forward will start a new conversation with the recipients that includes
+// the given messages. All of the conversation's messages are forwarded
+// if none are given.
+func (c *Conversation) Forward(recipients []string, body string, messages ...*ConversationMessage) ([]*Conversation, error) {
+	if len(messages) == 0 {
+		messages = c.Messages
+	}
+	ids := make([]string, len(messages))
+	for i, m := range messages {
+		ids[i] = strconv.Itoa(m.ID)
+	}
+	return createConversation(
+		c.client, recipients, c.Subject, body,
+		[]Option{ArrayOpt("forwarded_message_ids", ids...)},
+	)
+}
+
+// Update will update the conversation's workflow_state,
+// starred, or subscribed fields.
+//
+// https://canvas.instructure.com/doc/api/conversations.html#method.conversations.update
+func (c *Conversation) Update(opts ...Option) error {
+	return send(c.client, put, c.path(), optEnc(toPrefixedOpts("conversation", opts)), c)
+}
+
+// Delete will delete all of the messages in the conversation.
+//
+// https://canvas.instructure.com/doc/api/conversations.html#method.conversations.destroy
+func (c *Conversation) Delete() error {
+	resp, err := delete(c.client, c.path(), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// MarkRead will mark the conversation as read.
+func (c *Conversation) MarkRead() error {
+	return c.Update(Opt("workflow_state", "read"))
+}
+
+// MarkUnread will mark the conversation as unread.
+func (c *Conversation) MarkUnread() error {
+	return c.Update(Opt("workflow_state", "unread"))
+}
+
+// Archive will archive the conversation.
+func (c *Conversation) Archive() error {
+	return c.Update(Opt("workflow_state", "archived"))
+}
+
+// Unarchive will move the conversation out of the archive.
+func (c *Conversation) Unarchive() error {
+	return c.Update(Opt("workflow_state", "read"))
+}
+
+// Star will star the conversation.
+func (c *Conversation) Star() error {
+	return c.Update(Opt("starred", true))
+}
+
+// Unstar will unstar the conversation.
+func (c *Conversation) Unstar() error {
+	return c.Update(Opt("starred", false))
+}
+
+func (c *Conversation) path() string {
+	return fmt.Sprintf("/conversations/%d", c.ID)
+}
+
+func createConversation(d doer, recipients []string, subject, body string, opts []Option) ([]*Conversation, error) {
+	q := params{
+		"recipients[]": recipients,
+		"subject":      {subject},
+		"body":         {body},
+	}
+	q.Add(opts)
+	convs := make([]*Conversation, 0)
+	if err := send(d, post, "/conversations", q, &convs); err != nil {
+		return nil, err
+	}
+	for _, c := range convs {
+		c.client = d
+	}
+	return convs, nil
+}
+
+// ConversationIterator iterates over a paginated list of conversations.
+type ConversationIterator struct{ *iterator }
+
+// Value returns the current conversation.
+func (it *ConversationIterator) Value() *Conversation {
+	c, _ := it.cur.(*Conversation)
+	return c
+}
+
+func newConversationIterator(d doer, path string, opts []Option) *ConversationIterator {
+	return &ConversationIterator{newIterator(d, path, opts, func(r io.Reader, emit emitFunc) error {
+		convs := make([]*Conversation, 0)
+		if err := json.NewDecoder(r).Decode(&convs); err != nil {
+			return err
+		}
+		for _, c := range convs {
+			c.client = d
+			if err := emit(c); err != nil {
+				return err
+			}
+		}
+		return nil
+	})}
+}
+
+func collectConversations(it *ConversationIterator) ([]*Conversation, error) {
+	list := make([]*Conversation, 0)
+	err := collect(it.iterator, &list)
+	return list, err
+}
@@ -0,0 +1,130 @@
+package canvas
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestConversations(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	defer swapCanvas(&Canvas{client: cli})()
+
+	mux.HandleFunc("/api/v1/conversations", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.Method {
+		case "GET":
+			is.Equal(q.Get("scope"), ConversationUnread)
+			is.Equal(q["filter[]"], []string{"course_1"})
+			w.Write([]byte(`[{"id":2,"subject":"hi","audience":[3],
+				"audience_contexts":{"courses":{"1":["StudentEnrollment"]},"groups":{}},
+				"participants":[{"id":3,"name":"Jon"}],"properties":["last_author"]}]`))
+		case "POST":
+			is.Equal(q["recipients[]"], []string{"3", "course_1_teachers"})
+			is.Equal(q.Get("subject"), "hi")
+			is.Equal(q.Get("body"), "hello")
+			w.Write([]byte(`[{"id":2,"subject":"hi"}]`))
+		case "PUT":
+			is.Equal(q.Get("event"), string(ConversationArchive))
+			is.Equal(q["conversation_ids[]"], []string{"1", "2"})
+			w.Write([]byte(`{"id":9,"workflow_state":"queued"}`))
+		}
+	})
+	mux.HandleFunc("/api/v1/conversations/unread_count", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"unread_count":"7"}`))
+	})
+	mux.HandleFunc("/api/v1/search/recipients", func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(r.URL.Query().Get("search"), "jo")
+		w.Write([]byte(`[{"id":"course_1","name":"Course","type":"context","user_count":4},{"id":3,"name":"Jon","type":"user"}]`))
+	})
+
+	convs, err := Conversations(Opt("scope", ConversationUnread), ArrayOpt("filter", "course_1"))
+	is.NoErr(err)
+	is.Equal(len(convs), 1)
+	is.Equal(convs[0].Audience, []int{3})
+	is.Equal(convs[0].AudienceContexts.Courses["1"], []string{"StudentEnrollment"})
+	is.Equal(convs[0].Participants[0].Name, "Jon")
+	is.True(convs[0].client != nil)
+
+	convs, err = CreateConversation([]string{"3", "course_1_teachers"}, "hi", "hello")
+	is.NoErr(err)
+	is.Equal(convs[0].ID, 2)
+
+	p, err := UpdateConversations(ConversationArchive, []int{1, 2})
+	is.NoErr(err)
+	is.Equal(p.ID, 9)
+
+	n, err := UnreadConversations()
+	is.NoErr(err)
+	is.Equal(n, 7)
+
+	recipients, err := SearchRecipients("jo")
+	is.NoErr(err)
+	is.Equal(len(recipients), 2)
+	is.Equal(recipients[0].ID, "course_1")
+	is.Equal(recipients[0].UserCount, 4)
+	is.Equal(recipients[1].ID, "3")
+	is.Equal(recipients[1].Name, "Jon")
+}
+
+func TestConversation(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	c := &Canvas{client: cli}
+
+	var query map[string][]string
+	mux.HandleFunc("/api/v1/conversations/2", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`{"id":2,"subject":"hi","messages":[{"id":5,"body":"hello","author_id":3,"participating_user_ids":[1,3]}]}`))
+		case "PUT":
+			query = r.URL.Query()
+			w.Write([]byte(`{"id":2,"subject":"hi","workflow_state":"archived","starred":true}`))
+		case "DELETE":
+			w.Write([]byte(`{}`))
+		}
+	})
+	mux.HandleFunc("/api/v1/conversations/2/add_message", func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(r.Method, "POST")
+		is.Equal(r.URL.Query().Get("body"), "thanks")
+		is.NoErr(r.ParseForm())
+		is.Equal(r.Form["attachment_ids[]"], []string{"1", "2"})
+		w.Write([]byte(`{"id":2,"message_count":2,"messages":[{"id":6,"body":"thanks","author_id":1}]}`))
+	})
+	mux.HandleFunc("/api/v1/conversations", func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(r.Method, "POST")
+		q := r.URL.Query()
+		is.Equal(q.Get("subject"), "hi")
+		is.Equal(q["forwarded_message_ids[]"], []string{"6", "5"})
+		w.Write([]byte(`[{"id":4,"subject":"hi"}]`))
+	})
+
+	conv, err := c.GetConversation(2)
+	is.NoErr(err)
+	is.Equal(len(conv.Messages), 1)
+	is.Equal(conv.Messages[0].ParticipatingUserIDs, []int{1, 3})
+
+	msg, err := conv.Reply("thanks", ArrayOpt("attachment_ids", "1", "2"))
+	is.NoErr(err)
+	is.Equal(msg.ID, 6)
+	is.Equal(len(conv.Messages), 2)
+	is.Equal(conv.Messages[0].ID, 6)
+	is.Equal(conv.MessageCount, 2)
+	is.Equal(conv.Subject, "hi")
+
+	fwd, err := conv.Forward([]string{"7"}, "fyi")
+	is.NoErr(err)
+	is.Equal(fwd[0].ID, 4)
+
+	is.NoErr(conv.Archive())
+	is.Equal(query["conversation[workflow_state]"], []string{"archived"})
+	is.Equal(conv.WorkflowState, "archived")
+	is.NoErr(conv.Star())
+	is.Equal(query["conversation[starred]"], []string{"true"})
+	is.True(conv.Starred)
+	is.NoErr(conv.Delete())
+}
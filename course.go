@@ -1,6 +1,7 @@
 package canvas
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -34,7 +35,7 @@ type Course struct {
 	StartAt              time.Time     `json:"start_at"`
 	EndAt                time.Time     `json:"end_at"`
 	Locale               string        `json:"locale"`
-	Enrollments          []*Enrollment `json:"enrollments"`
+	CurrentEnrollments   []*Enrollment `json:"enrollments"`
 	TotalStudents        int           `json:"total_students"`
 	Calendar             struct {
 		// ICS Download is the download link for the calendar
@@ -91,6 +92,13 @@ type Course struct {
 	errorHandler errorHandlerFunc
 }
 
+// WithContext returns a copy of the course that uses ctx.
+func (c *Course) WithContext(ctx context.Context) *Course {
+	cp := *c
+	cp.client = withContext(c.client, ctx)
+	return &cp
+}
+
 // ContextCode will return the context code for this specific course.
 func (c *Course) ContextCode() string {
 	return fmt.Sprintf("course_%d", c.ID)
@@ -251,13 +259,18 @@ type CourseSettings struct {
 
 // Users will get a list of users in the course
 func (c *Course) Users(opts ...Option) (users []*User, err error) {
-	return c.collectUsers("/courses/%d/users", opts)
+	return collectUsers(c.UsersIter(opts...))
+}
+
+// UsersIter returns an iterator over the users in the course.
+func (c *Course) UsersIter(opts ...Option) *UserIterator {
+	return newUserIterator(c.client, c.id("/courses/%d/users"), opts)
 }
 
 // SearchUsers will search for a user in the course
 func (c *Course) SearchUsers(term string, opts ...Option) (users []*User, err error) {
 	opts = append(opts, Opt("search_term", term))
-	return c.collectUsers("/courses/%d/search_users", opts)
+	return collectUsers(newUserIterator(c.client, c.id("/courses/%d/search_users"), opts))
 }
 
 // User gets a specific user.
@@ -278,25 +291,48 @@ func (c *Course) Assignment(id int, opts ...Option) (ass *Assignment, err error)
 //
 // https://canvas.instructure.com/doc/api/assignments.html#method.assignments_api.index
 func (c *Course) Assignments(opts ...Option) <-chan *Assignment {
-	ch := make(assignmentChan)
-	pages := c.assignmentspager(ch, opts)
-	go handleErrs(pages.start(), ch, c.errorHandler)
+	it := c.AssignmentsIter(opts...)
+	it.handler = c.errorHandler
+	ch := make(chan *Assignment)
+	stream(it.iterator, ch)
 	return ch
 }
 
-// ListAssignments will get all the course assignments and put them in a slice.
-func (c *Course) ListAssignments(opts ...Option) (asses []*Assignment, err error) {
-	ch := make(assignmentChan)
-	pages := c.assignmentspager(ch, opts)
-	errs := pages.start()
-	for {
-		select {
-		case as := <-ch:
-			asses = append(asses, as)
-		case err = <-errs:
-			return asses, err
+// AssignmentsIter returns an iterator over the course's assignments.
+//
+// https://canvas.instructure.com/doc/api/assignments.html#method.assignments_api.index
+func (c *Course) AssignmentsIter(opts ...Option) *AssignmentIterator {
+	return &AssignmentIterator{newIterator(c.client, c.id("/courses/%d/assignments"), opts, func(r io.Reader, emit emitFunc) error {
+		asses := make([]*Assignment, 0, 10)
+		if err := json.NewDecoder(r).Decode(&asses); err != nil {
+			return err
 		}
-	}
+		for _, a := range asses {
+			a.client = c.client
+			a.courseCode = c.CourseCode
+			if err := emit(a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})}
+}
+
+// ListAssignments will get all the course assignments and put them in a slice.
+func (c *Course) ListAssignments(opts ...Option) ([]*Assignment, error) {
+	it := c.AssignmentsIter(opts...)
+	asses := make([]*Assignment, 0)
+	err := collect(it.iterator, &asses)
+	return asses, err
+}
+
+// AssignmentIterator iterates over a paginated list of assignments.
+type AssignmentIterator struct{ *iterator }
+
+// Value returns the current assignment.
+func (it *AssignmentIterator) Value() *Assignment {
+	a, _ := it.cur.(*Assignment)
+	return a
 }
 
 // CreateAssignment will create an assignment.
@@ -305,12 +341,13 @@ func (c *Course) CreateAssignment(a Assignment, opts ...Option) (*Assignment, er
 	if err != nil {
 		return nil, err
 	}
+	params(q).Add(opts)
 	resp, err := post(c.client, c.id("/courses/%d/assignments"), q)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
-	as := &Assignment{}
+	as := &Assignment{client: c.client, courseCode: c.CourseCode}
 	return as, json.NewDecoder(resp.Body).Decode(as)
 }
 
@@ -381,9 +418,11 @@ type Assignment struct {
 	CreatedAt time.Time `json:"created_at" url:"-"`
 	UpdatedAt time.Time `json:"updated_at" url:"-"`
 
-	Overrides              []AssignmentOverride `json:"overrides" url:"assignment_overrides,brackets,omitempty"`
-	OnlyVisibleToOverrides bool                 `json:"only_visible_to_overrides" url:"only_visible_to_overrides,omitempty"`
-	HasOverrides           bool                 `json:"has_overrides" url:"-"`
+	// Overrides is only set if the assignment was requested
+	// with the "overrides" include option.
+	Overrides              AssignmentOverrides `json:"overrides" url:"assignment_overrides,omitempty"`
+	OnlyVisibleToOverrides bool                `json:"only_visible_to_overrides" url:"only_visible_to_overrides,omitempty"`
+	HasOverrides           bool                `json:"has_overrides" url:"-"`
 
 	AssignmentGroupID              int               `json:"assignment_group_id" url:"assignment_group_id,omitempty"`
 	AllowedExtensions              []string          `json:"allowed_extensions" url:"allowed_extensions,brackets,omitempty"`
@@ -400,7 +439,7 @@ type Assignment struct {
 	IntegrationData                map[string]string `json:"integration_data" url:"integration_data,omitempty"`
 	NotifyOfUpdate                 bool              `json:"notify_of_update,omitempty" url:"notify_of_update,omitempty"`
 	PointsPossible                 float64           `json:"points_possible" url:"points_possible,omitempty"`
-	SubmissionTypes                []string          `json:"submission_types" url:"submission_types,brakets,omitempty"`
+	SubmissionTypes                []string          `json:"submission_types" url:"submission_types,brackets,omitempty"`
 	GradingType                    GradingType       `json:"grading_type" url:"grading_type,omitempty"`
 	GradingStandardID              interface{}       `json:"grading_standard_id" url:"grading_standard_id,omitempty"`
 	Published                      bool              `json:"published" url:"published,omitempty"`
@@ -432,7 +471,7 @@ type Assignment struct {
 	FreezeOnCopy            bool             `json:"freeze_on_copy" url:"-"`
 	Frozen                  bool             `json:"frozen" url:"-"`
 	FrozenAttributes        []string         `json:"frozen_attributes" url:"-"`
-	Submission              *Submission      `json:"submission" url:"-"`
+	CurrentSubmission       *Submission      `json:"submission" url:"-"`
 	UseRubricForGrading     bool             `json:"use_rubric_for_grading" url:"-"`
 	RubricSettings          interface{}      `json:"rubric_settings" url:"-"`
 	Rubric                  []RubricCriteria `json:"rubric" url:"-"`
@@ -453,8 +492,16 @@ type Assignment struct {
 	client     doer
 }
 
-// SubmitFile will submit the contents of an io.Reader as
-// a file to the assignment.
+// WithContext returns a copy of the assignment that uses ctx.
+func (a *Assignment) WithContext(ctx context.Context) *Assignment {
+	cp := *a
+	cp.client = withContext(a.client, ctx)
+	return &cp
+}
+
+// SubmitFile will upload the contents of an io.Reader as a file for
+// the assignment. The file is not submitted until its id is given to
+// Submit in the FileIDs of an OnlineUpload submission.
 //
 // https://canvas.instructure.com/doc/api/submissions.html#method.submissions.create
 func (a *Assignment) SubmitFile(filename string, r io.Reader, opts ...Option) (*File, error) {
@@ -497,24 +544,6 @@ type TurnitinSettings struct {
 	ExcludeSmallMatchesValue    int    `json:"exclude_small_matches_value"`
 }
 
-// RubricCriteria has the rubric information for an assignment.
-type RubricCriteria struct {
-	Points            float64 `json:"points"`
-	ID                string  `json:"id"`
-	LearningOutcomeID string  `json:"learning_outcome_id"`
-	VendorGUID        string  `json:"vendor_guid"`
-	Description       string  `json:"description"`
-	LongDescription   string  `json:"long_description"`
-	CriterionUseRange bool    `json:"criterion_use_range"`
-	Ratings           []struct {
-		ID              string  `json:"id"`
-		Description     string  `json:"description"`
-		LongDescription string  `json:"long_description"`
-		Points          float64 `json:"points"`
-	} `json:"ratings"`
-	IgnoreForScoring bool `json:"ignore_for_scoring"`
-}
-
 // LockInfo is a struct containing assignment lock status.
 type LockInfo struct {
 	AssetString    string    `json:"asset_string"`
@@ -524,39 +553,14 @@ type LockInfo struct {
 	ManuallyLocked bool      `json:"manually_locked"`
 }
 
-// AssignmentOverride is an assignment override object
-type AssignmentOverride struct {
-	ID              int       `json:"id" url:"-"`
-	Title           string    `json:"title" url:"title"`
-	StudentIds      []int     `json:"student_ids" url:"student_ids,brackets,omitempty"`
-	CourseSectionID int       `json:"course_section_id" url:"course_section_id"`
-	DueAt           time.Time `json:"due_at" url:"due_at,omitempty"`
-	UnlockAt        time.Time `json:"unlock_at" url:"unlock_at,omitempty"`
-	LockAt          time.Time `json:"lock_at" url:"lock_at,omitempty"`
-
-	AssignmentID int       `json:"assignment_id" url:"-"`
-	GroupID      int       `json:"group_id" url:"-"`
-	AllDay       bool      `json:"all_day" url:"-"`
-	AllDayDate   time.Time `json:"all_day_date" url:"-"`
-}
-
 // DiscussionTopics return a list of the course discussion topics.
 func (c *Course) DiscussionTopics(opts ...Option) ([]*DiscussionTopic, error) {
-	ch := make(chan *DiscussionTopic)
-	pager := newPaginatedList(
-		c.client, fmt.Sprintf("/courses/%d/discussion_topics", c.ID),
-		sendDiscussionTopicFunc(ch), opts,
-	)
-	topics := make([]*DiscussionTopic, 0)
-	errs := pager.start()
-	for {
-		select {
-		case disc := <-ch:
-			topics = append(topics, disc)
-		case err := <-errs:
-			return topics, err
-		}
-	}
+	return collectDiscussionTopics(c.DiscussionTopicsIter(opts...))
+}
+
+// DiscussionTopicsIter returns an iterator over the course discussion topics.
+func (c *Course) DiscussionTopicsIter(opts ...Option) *DiscussionTopicIterator {
+	return newDiscussionTopicIterator(c.client, c.id("/courses/%d/discussion_topics"), opts)
 }
 
 // Activity returns a course's activity data
@@ -566,7 +570,12 @@ func (c *Course) Activity() (res interface{}, err error) {
 
 // Files returns a channel of all the course's files
 func (c *Course) Files(opts ...Option) <-chan *File {
-	return filesChannel(c.client, c.id("/courses/%d/files"), c.errorHandler, opts, nil)
+	return filesChannel(c.FilesIter(opts...), c.errorHandler)
+}
+
+// FilesIter returns an iterator over the course's files.
+func (c *Course) FilesIter(opts ...Option) *FileIterator {
+	return newFileIterator(c.client, c.id("/courses/%d/files"), nil, opts)
 }
 
 // File will get a specific file id.
@@ -583,10 +592,13 @@ func (c *Course) ListFiles(opts ...Option) ([]*File, error) {
 // Folders will retrieve the course's folders.
 // https://canvas.instructure.com/doc/api/files.html#method.folders.list_all_folders
 func (c *Course) Folders(opts ...Option) <-chan *Folder {
-	ch := make(folderChan)
-	pager := c.folderspager(ch, opts)
-	go handleErrs(pager.start(), ch, c.errorHandler)
-	return ch
+	return foldersChannel(c.FoldersIter(opts...), c.errorHandler)
+}
+
+// FoldersIter returns an iterator over the course's folders.
+// https://canvas.instructure.com/doc/api/files.html#method.folders.list_all_folders
+func (c *Course) FoldersIter(opts ...Option) *FolderIterator {
+	return newFolderIterator(c.client, c.id("/courses/%d/folders"), nil, opts)
 }
 
 // Folder will the a folder from the course given a folder id.
@@ -634,7 +646,7 @@ func (c *Course) UploadFile(filename string, r io.Reader, opts ...Option) (*File
 
 // SetErrorHandler will set a error handling callback that is
 // used to handle errors in goroutines. The default error handler
-// will simply panic.
+// will close the channel that had the error.
 //
 // The callback should accept an error and a quit channel.
 // If a value is sent on the quit channel, whatever secsion of
@@ -663,248 +675,40 @@ type CourseProgress struct {
 	CompletedAt               time.Time `json:"completed_at"`
 }
 
-// Enrollment is an enrollment object
-// https://canvas.instructure.com/doc/api/enrollments.html
-type Enrollment struct {
-	ID                   int    `json:"id"`
-	CourseID             int    `json:"course_id"`
-	CourseIntegrationID  string `json:"course_integration_id"`
-	CourseSectionID      int    `json:"course_section_id"`
-	SectionIntegrationID string `json:"section_integration_id"`
-
-	EnrollmentState                string `json:"enrollment_state"`
-	Role                           string `json:"role"`
-	RoleID                         int    `json:"role_id"`
-	Type                           string `json:"type"`
-	LimitPrivilegesToCourseSection bool   `json:"limit_privileges_to_course_section"`
-	UserID                         int    `json:"user_id"`
-	User                           *User  `json:"user"`
-
-	SisCourseID      string      `json:"sis_course_id"`
-	SisAccountID     string      `json:"sis_account_id"`
-	SisSectionID     string      `json:"sis_section_id"`
-	SisUserID        string      `json:"sis_user_id"`
-	SisImportID      int         `json:"sis_import_id"`
-	RootAccountID    int         `json:"root_account_id"`
-	AssociatedUserID interface{} `json:"associated_user_id"`
-
-	CreatedAt         time.Time `json:"created_at"`
-	UpdatedAt         time.Time `json:"updated_at"`
-	StartAt           time.Time `json:"start_at"`
-	EndAt             time.Time `json:"end_at"`
-	LastActivityAt    time.Time `json:"last_activity_at"`
-	LastAttendedAt    time.Time `json:"last_attended_at"`
-	TotalActivityTime int       `json:"total_activity_time"`
-
-	HTMLURL string `json:"html_url"`
-	Grades  struct {
-		HTMLURL              string  `json:"html_url"`
-		CurrentScore         float64 `json:"current_score"`
-		CurrentGrade         string  `json:"current_grade"`
-		FinalScore           float64 `json:"final_score"`
-		FinalGrade           string  `json:"final_grade"`
-		UnpostedCurrentGrade string  `json:"unposted_current_grade"`
-		UnpostedFinalGrade   string  `json:"unposted_final_grade"`
-		UnpostedCurrentScore string  `json:"unposted_current_score"`
-		UnpostedFinalScore   string  `json:"unposted_final_score"`
-	} `json:"grades"`
-	OverrideGrade                     string  `json:"override_grade"`
-	OverrideScore                     float64 `json:"override_score"`
-	UnpostedCurrentGrade              string  `json:"unposted_current_grade"`
-	UnpostedFinalGrade                string  `json:"unposted_final_grade"`
-	UnpostedCurrentScore              string  `json:"unposted_current_score"`
-	UnpostedFinalScore                string  `json:"unposted_final_score"`
-	HasGradingPeriods                 bool    `json:"has_grading_periods"`
-	TotalsForAllGradingPeriodsOption  bool    `json:"totals_for_all_grading_periods_option"`
-	CurrentGradingPeriodTitle         string  `json:"current_grading_period_title"`
-	CurrentGradingPeriodID            int     `json:"current_grading_period_id"`
-	CurrentPeriodOverrideGrade        string  `json:"current_period_override_grade"`
-	CurrentPeriodOverrideScore        float64 `json:"current_period_override_score"`
-	CurrentPeriodUnpostedCurrentScore float64 `json:"current_period_unposted_current_score"`
-	CurrentPeriodUnpostedFinalScore   float64 `json:"current_period_unposted_final_score"`
-	CurrentPeriodUnpostedCurrentGrade string  `json:"current_period_unposted_current_grade"`
-	CurrentPeriodUnpostedFinalGrade   string  `json:"current_period_unposted_final_grade"`
-}
-
-// Quizzes will get all the course quizzes
-func (c *Course) Quizzes(opts ...Option) ([]*Quiz, error) {
-	return getQuizzes(c.client, c.ID, opts)
-}
-
-// Quiz will return a quiz given a quiz id.
-func (c *Course) Quiz(id int, opts ...Option) (*Quiz, error) {
-	return getQuiz(c.client, c.ID, id, opts)
-}
-
-func getQuizzes(client doer, courseID int, opts []Option) (qs []*Quiz, err error) {
-	return qs, getjson(client, &qs, optEnc(opts), "courses/%d/quizzes", courseID)
-}
-
-func getQuiz(client doer, course, quiz int, opts []Option) (q *Quiz, err error) {
-	return q, getjson(client, q, optEnc(opts), "courses/%d/quizzes/%d", course, quiz)
-}
-
-// Quiz is a quiz json response.
-type Quiz struct {
-	ID       int       `json:"id"`
-	Title    string    `json:"title"`
-	DueAt    time.Time `json:"due_at"`
-	LockAt   time.Time `json:"lock_at"`
-	UnlockAt time.Time `json:"unlock_at"`
-
-	HTMLURL                       string          `json:"html_url"`
-	MobileURL                     string          `json:"mobile_url"`
-	PreviewURL                    string          `json:"preview_url"`
-	Description                   string          `json:"description"`
-	QuizType                      string          `json:"quiz_type"`
-	AssignmentGroupID             int             `json:"assignment_group_id"`
-	TimeLimit                     int             `json:"time_limit"`
-	ShuffleAnswers                bool            `json:"shuffle_answers"`
-	HideResults                   string          `json:"hide_results"`
-	ShowCorrectAnswers            bool            `json:"show_correct_answers"`
-	ShowCorrectAnswersLastAttempt bool            `json:"show_correct_answers_last_attempt"`
-	ShowCorrectAnswersAt          time.Time       `json:"show_correct_answers_at"`
-	HideCorrectAnswersAt          time.Time       `json:"hide_correct_answers_at"`
-	OneTimeResults                bool            `json:"one_time_results"`
-	ScoringPolicy                 string          `json:"scoring_policy"`
-	AllowedAttempts               int             `json:"allowed_attempts"`
-	OneQuestionAtATime            bool            `json:"one_question_at_a_time"`
-	QuestionCount                 int             `json:"question_count"`
-	PointsPossible                int             `json:"points_possible"`
-	CantGoBack                    bool            `json:"cant_go_back"`
-	AccessCode                    string          `json:"access_code"`
-	IPFilter                      string          `json:"ip_filter"`
-	Published                     bool            `json:"published"`
-	Unpublishable                 bool            `json:"unpublishable"`
-	LockedForUser                 bool            `json:"locked_for_user"`
-	LockInfo                      interface{}     `json:"lock_info"`
-	LockExplanation               string          `json:"lock_explanation"`
-	SpeedgraderURL                string          `json:"speedgrader_url"`
-	QuizExtensionsURL             string          `json:"quiz_extensions_url"`
-	Permissions                   QuizPermissions `json:"permissions"`
-	AllDates                      []string        `json:"all_dates"`
-	VersionNumber                 int             `json:"version_number"`
-	QuestionTypes                 []string        `json:"question_types"`
-	AnonymousSubmissions          bool            `json:"anonymous_submissions"`
-}
-
-// QuizPermissions is the permissions for a quiz.
-type QuizPermissions struct {
-	Read           bool `json:"read"`
-	Submit         bool `json:"submit"`
-	Create         bool `json:"create"`
-	Manage         bool `json:"manage"`
-	ReadStatistics bool `json:"read_statistics"`
-	ReviewGrades   bool `json:"review_grades"`
-	Update         bool `json:"update"`
-}
-
-func (c *Course) filespager(ch chan *File, params []Option) *paginated {
-	return newPaginatedList(
-		c.client, c.id("/courses/%d/files"),
-		sendFilesFunc(c.client, ch, nil),
-		params,
-	)
-}
-
-func (c *Course) folderspager(ch chan *Folder, params []Option) *paginated {
-	return newPaginatedList(
-		c.client, c.id("/courses/%d/folders"),
-		sendFoldersFunc(c.client, ch, nil),
-		params,
-	)
-}
-
-func (c *Course) assignmentspager(ch chan *Assignment, params []Option) *paginated {
-	return newPaginatedList(
-		c.client, c.id("/courses/%d/assignments"),
-		func(r io.Reader) error {
-			asses := make([]*Assignment, 0, 10)
-			err := json.NewDecoder(r).Decode(&asses)
-			if err != nil {
-				return err
-			}
-			for _, a := range asses {
-				a.client = c.client
-				a.courseCode = c.CourseCode
-				ch <- a
-			}
-			return nil
-		}, params,
-	)
-}
-
-func (c *Course) collectUsers(path string, opts []Option) (users []*User, err error) {
-	ch := make(chan *User)
-	errs := newPaginatedList(
-		c.client, fmt.Sprintf(path, c.ID),
-		sendUserFunc(c.client, ch), opts,
-	).start()
-	for {
-		select {
-		case u := <-ch:
-			users = append(users, u)
-		case err := <-errs:
-			return users, err
-		}
-	}
-}
-
-func sendFilesFunc(d doer, ch chan *File, folder *Folder) func(io.Reader) error {
-	return func(r io.Reader) error {
-		files := make([]*File, 0, defaultPerPage)
-		err := json.NewDecoder(r).Decode(&files)
-		if err != nil {
-			return err
-		}
-		for _, f := range files {
-			f.setclient(d)
-			f.folder = folder
-			ch <- f
-		}
-		return nil
-	}
-}
+// UserIterator iterates over a paginated list of users.
+type UserIterator struct{ *iterator }
 
-func sendFoldersFunc(d doer, ch chan *Folder, parent *Folder) sendFunc {
-	return func(r io.Reader) error {
-		folders := make([]*Folder, 0, defaultPerPage)
-		err := json.NewDecoder(r).Decode(&folders)
-		if err != nil {
-			return err
-		}
-		for _, f := range folders {
-			f.setclient(d)
-			f.parent = parent
-			ch <- f
-		}
-		return nil
-	}
+// Value returns the current user.
+func (it *UserIterator) Value() *User {
+	u, _ := it.cur.(*User)
+	return u
 }
 
-func sendUserFunc(d doer, ch chan *User) sendFunc {
-	return func(r io.Reader) error {
+func newUserIterator(d doer, path string, opts []Option) *UserIterator {
+	return &UserIterator{newIterator(d, path, opts, func(r io.Reader, emit emitFunc) error {
 		list := make([]*User, 0, defaultPerPage)
-		err := json.NewDecoder(r).Decode(&list)
-		if err != nil {
+		if err := json.NewDecoder(r).Decode(&list); err != nil {
 			return err
 		}
 		for _, u := range list {
 			u.client = d
-			ch <- u
+			if err := emit(u); err != nil {
+				return err
+			}
 		}
 		return nil
-	}
+	})}
 }
 
-func defaultErrorHandler(err error) error {
-	panic(err)
+func collectUsers(it *UserIterator) ([]*User, error) {
+	users := make([]*User, 0)
+	err := collect(it.iterator, &users)
+	return users, err
 }
 
-type assignmentChan chan *Assignment
-
-func (ac assignmentChan) Close() {
-	close(ac)
+// defaultErrorHandler stops the channel that had the error.
+func defaultErrorHandler(err error) error {
+	return err
 }
 
 func (c *Course) id(s string) string {
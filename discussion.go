@@ -0,0 +1,624 @@
+package canvas
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harrybrwn/go-querystring/query"
+)
+
+// Discussion types
+const (
+	SideComment = "side_comment"
+	Threaded    = "threaded"
+)
+
+// DiscussionTopic is a discussion topic
+type DiscussionTopic struct {
+	ID                      int       `json:"id" url:"-"`
+	Title                   string    `json:"title" url:"title,omitempty"`
+	Message                 string    `json:"message" url:"message,omitempty"`
+	HTMLURL                 string    `json:"html_url" url:"-"`
+	PostedAt                time.Time `json:"posted_at" url:"-"`
+	LastReplyAt             time.Time `json:"last_reply_at" url:"-"`
+	RequireInitialPost      bool      `json:"require_initial_post" url:"require_initial_post,omitempty"`
+	UserCanSeePosts         bool      `json:"user_can_see_posts" url:"-"`
+	DiscussionSubentryCount int       `json:"discussion_subentry_count" url:"-"`
+	ReadState               string    `json:"read_state" url:"-"`
+	UnreadCount             int       `json:"unread_count" url:"-"`
+	Subscribed              bool      `json:"subscribed" url:"-"`
+	SubscriptionHold        string    `json:"subscription_hold" url:"-"`
+	AssignmentID            int       `json:"assignment_id" url:"-"`
+	// DelayedPostAt is when the topic will be posted.
+	// The topic is posted right away if it is not set.
+	DelayedPostAt time.Time `json:"delayed_post_at" url:"delayed_post_at,omitempty"`
+	// LockAt is when the topic will be locked.
+	LockAt             time.Time   `json:"lock_at" url:"lock_at,omitempty"`
+	Published          bool        `json:"published" url:"published,omitempty"`
+	Locked             bool        `json:"locked" url:"locked,omitempty"`
+	Pinned             bool        `json:"pinned" url:"pinned,omitempty"`
+	LockedForUser      bool        `json:"locked_for_user" url:"-"`
+	LockInfo           interface{} `json:"lock_info" url:"-"`
+	LockExplanation    string      `json:"lock_explanation" url:"-"`
+	UserName           string      `json:"user_name" url:"-"`
+	TopicChildren      []int       `json:"topic_children" url:"-"`
+	GroupTopicChildren []struct {
+		ID      int `json:"id"`
+		GroupID int `json:"group_id"`
+	} `json:"group_topic_children" url:"-"`
+	RootTopicID int    `json:"root_topic_id" url:"-"`
+	PodcastURL  string `json:"podcast_url" url:"-"`
+	// DiscussionType is either SideComment or Threaded.
+	DiscussionType  string  `json:"discussion_type" url:"discussion_type,omitempty"`
+	GroupCategoryID int     `json:"group_category_id" url:"group_category_id,omitempty"`
+	Attachments     []*File `json:"attachments" url:"-"`
+	Permissions     struct {
+		Attach bool `json:"attach"`
+	} `json:"permissions" url:"-"`
+	AllowRating        bool `json:"allow_rating" url:"allow_rating,omitempty"`
+	OnlyGradersCanRate bool `json:"only_graders_can_rate" url:"only_graders_can_rate,omitempty"`
+	SortByRating       bool `json:"sort_by_rating" url:"sort_by_rating,omitempty"`
+	IsAnnouncement     bool `json:"is_announcement" url:"is_announcement,omitempty"`
+	// ContextCode is only set for announcements.
+	ContextCode string `json:"context_code" url:"-"`
+
+	// path of the course or group that owns the topic
+	ctxPath string
+	client  doer
+}
+
+// DiscussionEntry is an entry or reply in a discussion topic.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_entries.index
+type DiscussionEntry struct {
+	ID              int       `json:"id"`
+	UserID          int       `json:"user_id"`
+	ParentID        int       `json:"parent_id"`
+	EditorID        int       `json:"editor_id"`
+	UserName        string    `json:"user_name"`
+	Message         string    `json:"message"`
+	ReadState       string    `json:"read_state"`
+	ForcedReadState bool      `json:"forced_read_state"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	Deleted         bool      `json:"deleted"`
+	RatingCount     int       `json:"rating_count"`
+	RatingSum       int       `json:"rating_sum"`
+	Attachment      *File     `json:"attachment"`
+	// RecentReplies is only set for entries listed with Entries.
+	RecentReplies  []*DiscussionEntry `json:"recent_replies"`
+	HasMoreReplies bool               `json:"has_more_replies"`
+	// Children are the replies to the entry and
+	// are only set for entries in a DiscussionView.
+	Children []*DiscussionEntry `json:"replies"`
+
+	topicPath string
+	client    doer
+}
+
+// DiscussionView is the full threaded view of a discussion topic.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics_api.view
+type DiscussionView struct {
+	UnreadEntries []int `json:"unread_entries"`
+	ForcedEntries []int `json:"forced_entries"`
+	// EntryRatings maps entry ids to the
+	// current user's rating of that entry.
+	EntryRatings map[int]int             `json:"entry_ratings"`
+	Participants []DiscussionParticipant `json:"participants"`
+	// Entries are the top level entries in the discussion,
+	// their replies are in each entry's Children.
+	Entries    []*DiscussionEntry `json:"view"`
+	NewEntries []*DiscussionEntry `json:"new_entries"`
+}
+
+// DiscussionParticipant is a user that has taken part in a discussion.
+type DiscussionParticipant struct {
+	ID             int    `json:"id"`
+	DisplayName    string `json:"display_name"`
+	AvatarImageURL string `json:"avatar_image_url"`
+	HTMLURL        string `json:"html_url"`
+}
+
+// Walk will call fn for every entry in the view in the order that they
+// appear in the thread. The depth of top level entries is zero.
+func (v *DiscussionView) Walk(fn func(e *DiscussionEntry, depth int) error) error {
+	return walkEntries(v.Entries, 0, fn)
+}
+
+func walkEntries(entries []*DiscussionEntry, depth int, fn func(*DiscussionEntry, int) error) error {
+	for _, e := range entries {
+		if err := fn(e, depth); err != nil {
+			return err
+		}
+		if err := walkEntries(e.Children, depth+1, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WithContext returns a copy of the discussion topic that uses ctx.
+func (t *DiscussionTopic) WithContext(ctx context.Context) *DiscussionTopic {
+	cp := *t
+	cp.client = withContext(t.client, ctx)
+	return &cp
+}
+
+// WithContext returns a copy of the discussion entry that uses ctx.
+func (e *DiscussionEntry) WithContext(ctx context.Context) *DiscussionEntry {
+	cp := *e
+	cp.client = withContext(e.client, ctx)
+	return &cp
+}
+
+// DiscussionTopic will get one of the course's discussion topics.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics_api.show
+func (c *Course) DiscussionTopic(id int, opts ...Option) (*DiscussionTopic, error) {
+	return getDiscussionTopic(c.client, c.id("/courses/%d"), id, opts)
+}
+
+// CreateDiscussionTopic will create a new discussion topic in the course.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics.create
+func (c *Course) CreateDiscussionTopic(t DiscussionTopic, opts ...Option) (*DiscussionTopic, error) {
+	return createDiscussionTopic(c.client, c.id("/courses/%d"), t, opts)
+}
+
+// DiscussionTopic will get one of the group's discussion topics.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics_api.show
+func (g *Group) DiscussionTopic(id int, opts ...Option) (*DiscussionTopic, error) {
+	return getDiscussionTopic(g.client, g.id("/groups/%d"), id, opts)
+}
+
+// CreateDiscussionTopic will create a new discussion topic in the group.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics.create
+func (g *Group) CreateDiscussionTopic(t DiscussionTopic, opts ...Option) (*DiscussionTopic, error) {
+	return createDiscussionTopic(g.client, g.id("/groups/%d"), t, opts)
+}
+
+// Update will send the topic's fields to canvas and replace them with the
+// updated topic. Empty fields are left out so Update cannot unpublish the
+// topic, use Unpublish instead. Options can be used to send other values
+// that are left out when they are empty.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics.update
+func (t *DiscussionTopic) Update(opts ...Option) error {
+	q, err := query.Values(t)
+	if err != nil {
+		return err
+	}
+	params(q).Add(opts)
+	return t.send(put, t.path(), q)
+}
+
+// Publish will publish the topic.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics.update
+func (t *DiscussionTopic) Publish() error {
+	return t.send(put, t.path(), params{"published": {"true"}})
+}
+
+// Unpublish will unpublish the topic. Topics that have replies
+// from students cannot be unpublished.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics.update
+func (t *DiscussionTopic) Unpublish() error {
+	return t.send(put, t.path(), params{"published": {"false"}})
+}
+
+// Delete will delete the topic along with all of its entries.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics.destroy
+func (t *DiscussionTopic) Delete() error {
+	resp, err := delete(t.client, t.path(), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (t *DiscussionTopic) send(
+	method func(doer, string, encoder) (*http.Response, error),
+	path string,
+	q encoder,
+) error {
+	if err := send(t.client, method, path, q, t); err != nil {
+		return err
+	}
+	t.setclient(t.client, t.ctxPath)
+	return nil
+}
+
+// Entries will get the top level entries in the topic.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics_api.entries
+func (t *DiscussionTopic) Entries(opts ...Option) ([]*DiscussionEntry, error) {
+	return collectDiscussionEntries(t.EntriesIter(opts...))
+}
+
+// EntriesIter returns an iterator over the top level entries in the topic.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics_api.entries
+func (t *DiscussionTopic) EntriesIter(opts ...Option) *DiscussionEntryIterator {
+	return newDiscussionEntryIterator(t.client, t.path(), t.path()+"/entries", opts)
+}
+
+// PostEntry will post a new entry to the topic.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics_api.add_entry
+func (t *DiscussionTopic) PostEntry(message string) (*DiscussionEntry, error) {
+	return postEntry(t.client, t.path(), t.path()+"/entries", message, "", nil)
+}
+
+// PostEntryWithAttachment will post a new entry to the topic
+// with the contents of r attached as a file.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics_api.add_entry
+func (t *DiscussionTopic) PostEntryWithAttachment(message, filename string, r io.Reader) (*DiscussionEntry, error) {
+	return postEntry(t.client, t.path(), t.path()+"/entries", message, filename, r)
+}
+
+// View will get the full threaded view of the topic.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics_api.view
+func (t *DiscussionTopic) View(opts ...Option) (*DiscussionView, error) {
+	v := &DiscussionView{}
+	if err := getjson(t.client, v, optEnc(opts), t.path()+"/view"); err != nil {
+		return nil, err
+	}
+	path := t.path()
+	v.Walk(func(e *DiscussionEntry, _ int) error {
+		e.setclient(t.client, path)
+		return nil
+	})
+	for _, e := range v.NewEntries {
+		e.setclient(t.client, path)
+	}
+	return v, nil
+}
+
+// MarkRead will mark the topic as read.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics_api.mark_topic_read
+func (t *DiscussionTopic) MarkRead() error {
+	return t.action(put, "/read", nil)
+}
+
+// MarkUnread will mark the topic as unread.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics_api.mark_topic_unread
+func (t *DiscussionTopic) MarkUnread() error {
+	return t.action(delete, "/read", nil)
+}
+
+// MarkAllRead will mark the topic and all of its entries as read.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics_api.mark_all_read
+func (t *DiscussionTopic) MarkAllRead(opts ...Option) error {
+	return t.action(put, "/read_all", optEnc(opts))
+}
+
+// MarkAllUnread will mark the topic and all of its entries as unread.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics_api.mark_all_unread
+func (t *DiscussionTopic) MarkAllUnread(opts ...Option) error {
+	return t.action(delete, "/read_all", optEnc(opts))
+}
+
+// Subscribe will subscribe the current user to the topic.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics_api.subscribe_topic
+func (t *DiscussionTopic) Subscribe() error {
+	if err := t.action(put, "/subscribed", nil); err != nil {
+		return err
+	}
+	t.Subscribed = true
+	return nil
+}
+
+// Unsubscribe will unsubscribe the current user from the topic.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics_api.unsubscribe_topic
+func (t *DiscussionTopic) Unsubscribe() error {
+	if err := t.action(delete, "/subscribed", nil); err != nil {
+		return err
+	}
+	t.Subscribed = false
+	return nil
+}
+
+func (t *DiscussionTopic) action(
+	method func(doer, string, encoder) (*http.Response, error),
+	action string,
+	q encoder,
+) error {
+	resp, err := method(t.client, t.path()+action, q)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (t *DiscussionTopic) path() string {
+	ctxPath := t.ctxPath
+	if ctxPath == "" {
+		ctxPath = pathFromContextCode(t.ContextCode)
+	}
+	return fmt.Sprintf("%s/discussion_topics/%d", ctxPath, t.ID)
+}
+
+func (t *DiscussionTopic) setclient(d doer, ctxPath string) {
+	t.client = d
+	t.ctxPath = ctxPath
+	for _, f := range t.Attachments {
+		f.setclient(d)
+	}
+}
+
+// Replies will get the replies to the entry.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics_api.replies
+func (e *DiscussionEntry) Replies(opts ...Option) ([]*DiscussionEntry, error) {
+	return collectDiscussionEntries(e.RepliesIter(opts...))
+}
+
+// RepliesIter returns an iterator over the replies to the entry.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics_api.replies
+func (e *DiscussionEntry) RepliesIter(opts ...Option) *DiscussionEntryIterator {
+	return newDiscussionEntryIterator(e.client, e.topicPath, e.path()+"/replies", opts)
+}
+
+// PostReply will post a reply to the entry.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics_api.add_reply
+func (e *DiscussionEntry) PostReply(message string) (*DiscussionEntry, error) {
+	return postEntry(e.client, e.topicPath, e.path()+"/replies", message, "", nil)
+}
+
+// PostReplyWithAttachment will post a reply to the entry
+// with the contents of r attached as a file.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics_api.add_reply
+func (e *DiscussionEntry) PostReplyWithAttachment(message, filename string, r io.Reader) (*DiscussionEntry, error) {
+	return postEntry(e.client, e.topicPath, e.path()+"/replies", message, filename, r)
+}
+
+// Update will change the message of the entry.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_entries.update
+func (e *DiscussionEntry) Update(message string) error {
+	if err := send(e.client, put, e.path(), params{"message": {message}}, e); err != nil {
+		return err
+	}
+	e.setclient(e.client, e.topicPath)
+	return nil
+}
+
+// Delete will delete the entry.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_entries.destroy
+func (e *DiscussionEntry) Delete() error {
+	return e.action(delete, "", nil)
+}
+
+// MarkRead will mark the entry as read.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics_api.mark_entry_read
+func (e *DiscussionEntry) MarkRead(opts ...Option) error {
+	if err := e.action(put, "/read", optEnc(opts)); err != nil {
+		return err
+	}
+	e.ReadState = "read"
+	return nil
+}
+
+// MarkUnread will mark the entry as unread.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics_api.mark_entry_unread
+func (e *DiscussionEntry) MarkUnread(opts ...Option) error {
+	if err := e.action(delete, "/read", optEnc(opts)); err != nil {
+		return err
+	}
+	e.ReadState = "unread"
+	return nil
+}
+
+// Rate will rate the entry. The rating should be 1 to like
+// the entry or 0 to remove the like.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics_api.rate_entry
+func (e *DiscussionEntry) Rate(rating int) error {
+	return e.action(post, "/rating", params{"rating": {strconv.Itoa(rating)}})
+}
+
+func (e *DiscussionEntry) action(
+	method func(doer, string, encoder) (*http.Response, error),
+	action string,
+	q encoder,
+) error {
+	resp, err := method(e.client, e.path()+action, q)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (e *DiscussionEntry) path() string {
+	return fmt.Sprintf("%s/entries/%d", e.topicPath, e.ID)
+}
+
+func (e *DiscussionEntry) setclient(d doer, topicPath string) {
+	e.client = d
+	e.topicPath = topicPath
+	if e.Attachment != nil {
+		e.Attachment.setclient(d)
+	}
+	for _, r := range e.RecentReplies {
+		r.setclient(d, topicPath)
+	}
+}
+
+func getDiscussionTopic(d doer, ctxPath string, id int, opts []Option) (*DiscussionTopic, error) {
+	t := &DiscussionTopic{}
+	if err := getjson(d, t, optEnc(opts), "%s/discussion_topics/%d", ctxPath, id); err != nil {
+		return nil, err
+	}
+	t.setclient(d, ctxPath)
+	return t, nil
+}
+
+func createDiscussionTopic(d doer, ctxPath string, t DiscussionTopic, opts []Option) (*DiscussionTopic, error) {
+	q, err := query.Values(&t)
+	if err != nil {
+		return nil, err
+	}
+	params(q).Add(opts)
+	topic := &DiscussionTopic{}
+	if err = send(d, post, ctxPath+"/discussion_topics", q, topic); err != nil {
+		return nil, err
+	}
+	topic.setclient(d, ctxPath)
+	return topic, nil
+}
+
+// postEntry posts a message to the entries or replies endpoint. When r
+// is not nil the message is sent as a multipart form with r attached.
+func postEntry(d doer, topicPath, path, message, filename string, r io.Reader) (*DiscussionEntry, error) {
+	var (
+		resp *http.Response
+		err  error
+	)
+	if r == nil {
+		resp, err = post(d, path, params{"message": {message}})
+	} else {
+		resp, err = postAttachment(d, path, message, filename, r)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	e := &DiscussionEntry{}
+	if err = json.NewDecoder(resp.Body).Decode(e); err != nil {
+		return nil, err
+	}
+	e.setclient(d, topicPath)
+	return e, nil
+}
+
+func postAttachment(d doer, path, message, filename string, r io.Reader) (*http.Response, error) {
+	buf := &bytes.Buffer{}
+	w := multipart.NewWriter(buf)
+	if err := w.WriteField("message", message); err != nil {
+		return nil, err
+	}
+	form, err := w.CreateFormFile("attachment", filename)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = io.Copy(form, r); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	body := buf.Bytes()
+	req := newreq("POST", path, nil)
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(body)), nil
+	}
+	req.ContentLength = int64(len(body))
+	req.Header = http.Header{"Content-Type": {w.FormDataContentType()}}
+	return do(d, req)
+}
+
+// pathFromContextCode converts a context code (ex. "course_1")
+// into the api path of that context (ex. "/courses/1").
+func pathFromContextCode(code string) string {
+	i := strings.LastIndexByte(code, '_')
+	if i < 0 {
+		return ""
+	}
+	return fmt.Sprintf("/%ss/%s", code[:i], code[i+1:])
+}
+
+// DiscussionTopicIterator iterates over a paginated list of discussion topics.
+type DiscussionTopicIterator struct{ *iterator }
+
+// Value returns the current discussion topic.
+func (it *DiscussionTopicIterator) Value() *DiscussionTopic {
+	t, _ := it.cur.(*DiscussionTopic)
+	return t
+}
+
+func newDiscussionTopicIterator(d doer, path string, opts []Option) *DiscussionTopicIterator {
+	ctxPath := strings.TrimSuffix(path, "/discussion_topics")
+	if ctxPath == path {
+		// announcements are found using each topic's context code
+		ctxPath = ""
+	}
+	return &DiscussionTopicIterator{newIterator(d, path, opts, func(r io.Reader, emit emitFunc) error {
+		discs := make([]*DiscussionTopic, 0)
+		if err := json.NewDecoder(r).Decode(&discs); err != nil {
+			return err
+		}
+		for _, t := range discs {
+			t.setclient(d, ctxPath)
+			if err := emit(t); err != nil {
+				return err
+			}
+		}
+		return nil
+	})}
+}
+
+func collectDiscussionTopics(it *DiscussionTopicIterator) ([]*DiscussionTopic, error) {
+	topics := make([]*DiscussionTopic, 0)
+	err := collect(it.iterator, &topics)
+	return topics, err
+}
+
+// DiscussionEntryIterator iterates over a paginated list of discussion entries.
+type DiscussionEntryIterator struct{ *iterator }
+
+// Value returns the current discussion entry.
+func (it *DiscussionEntryIterator) Value() *DiscussionEntry {
+	e, _ := it.cur.(*DiscussionEntry)
+	return e
+}
+
+func newDiscussionEntryIterator(d doer, topicPath, path string, opts []Option) *DiscussionEntryIterator {
+	return &DiscussionEntryIterator{newIterator(d, path, opts, func(r io.Reader, emit emitFunc) error {
+		list := make([]*DiscussionEntry, 0, defaultPerPage)
+		if err := json.NewDecoder(r).Decode(&list); err != nil {
+			return err
+		}
+		for _, e := range list {
+			e.setclient(d, topicPath)
+			if err := emit(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	})}
+}
+
+func collectDiscussionEntries(it *DiscussionEntryIterator) ([]*DiscussionEntry, error) {
+	entries := make([]*DiscussionEntry, 0)
+	err := collect(it.iterator, &entries)
+	return entries, err
+}
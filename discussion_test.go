@@ -0,0 +1,217 @@
+package canvas
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestCourse_DiscussionTopic(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	c := &Course{ID: 1, client: cli}
+
+	mux.HandleFunc("/api/v1/courses/1/discussion_topics", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`[{"id":5,"title":"first"},{"id":6,"title":"second"}]`))
+		case "POST":
+			q := r.URL.Query()
+			is.Equal(q.Get("title"), "Week 1")
+			is.Equal(q.Get("discussion_type"), "threaded")
+			is.Equal(q.Get("published"), "true")
+			is.Equal(len(q["pinned"]), 0)
+			w.Write([]byte(`{"id":5,"title":"Week 1","discussion_type":"threaded","published":true}`))
+		}
+	})
+	var method string
+	var query map[string][]string
+	mux.HandleFunc("/api/v1/courses/1/discussion_topics/5", func(w http.ResponseWriter, r *http.Request) {
+		method, query = r.Method, r.URL.Query()
+		w.Write([]byte(`{"id":5,"title":"Week 1","attachments":[{"id":569}]}`))
+	})
+
+	topics, err := c.DiscussionTopics()
+	is.NoErr(err)
+	is.Equal(len(topics), 2)
+	is.Equal(topics[0].path(), "/courses/1/discussion_topics/5")
+	is.True(topics[0].client != nil)
+
+	topic, err := c.CreateDiscussionTopic(DiscussionTopic{Title: "Week 1", DiscussionType: Threaded, Published: true})
+	is.NoErr(err)
+	is.Equal(topic.ID, 5)
+
+	topic, err = c.DiscussionTopic(5)
+	is.NoErr(err)
+	is.Equal(len(topic.Attachments), 1)
+	is.True(topic.Attachments[0].client != nil)
+
+	topic.Title = "Week 2"
+	is.NoErr(topic.Update(Opt("published", false)))
+	is.Equal(method, "PUT")
+	is.Equal(query["title"], []string{"Week 2"})
+	is.Equal(query["published"], []string{"false"})
+
+	is.NoErr(topic.Unpublish())
+	is.Equal(method, "PUT")
+	is.Equal(query["published"], []string{"false"})
+	is.Equal(len(query["title"]), 0)
+	is.NoErr(topic.Publish())
+	is.Equal(query["published"], []string{"true"})
+
+	is.NoErr(topic.Delete())
+	is.Equal(method, "DELETE")
+
+	// announcements use their context code
+	a := &DiscussionTopic{ID: 3, ContextCode: "group_17"}
+	is.Equal(a.path(), "/groups/17/discussion_topics/3")
+}
+
+func TestDiscussionTopic_Entries(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	topic := &DiscussionTopic{ID: 5, ctxPath: "/courses/1", client: cli}
+
+	mux.HandleFunc("/api/v1/courses/1/discussion_topics/5/entries", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`[{"id":1,"user_id":2,"message":"hi","recent_replies":[{"id":2,"parent_id":1,"message":"hello"}],"has_more_replies":true}]`))
+		case "POST":
+			if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+				is.NoErr(r.ParseMultipartForm(1 << 20))
+				is.Equal(r.FormValue("message"), "see attached")
+				f, h, err := r.FormFile("attachment")
+				is.NoErr(err)
+				is.Equal(h.Filename, "notes.txt")
+				b, _ := ioutil.ReadAll(f)
+				is.Equal(string(b), "my notes")
+				w.Write([]byte(`{"id":3,"message":"see attached","attachment":{"id":77,"filename":"notes.txt"}}`))
+				return
+			}
+			is.Equal(r.URL.Query().Get("message"), "first")
+			w.Write([]byte(`{"id":3,"message":"first"}`))
+		}
+	})
+	mux.HandleFunc("/api/v1/courses/1/discussion_topics/5/entries/1/replies", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`[{"id":2,"parent_id":1,"message":"hello"},{"id":4,"parent_id":1,"message":"hey"}]`))
+		case "POST":
+			is.Equal(r.URL.Query().Get("message"), "reply")
+			w.Write([]byte(`{"id":5,"parent_id":1,"message":"reply"}`))
+		}
+	})
+
+	entries, err := topic.Entries()
+	is.NoErr(err)
+	is.Equal(len(entries), 1)
+	is.Equal(entries[0].RecentReplies[0].topicPath, "/courses/1/discussion_topics/5")
+
+	replies, err := entries[0].Replies()
+	is.NoErr(err)
+	is.Equal(len(replies), 2)
+	is.Equal(replies[1].Message, "hey")
+
+	e, err := topic.PostEntry("first")
+	is.NoErr(err)
+	is.Equal(e.ID, 3)
+
+	e, err = topic.PostEntryWithAttachment("see attached", "notes.txt", strings.NewReader("my notes"))
+	is.NoErr(err)
+	is.Equal(e.Attachment.ID, 77)
+
+	e, err = entries[0].PostReply("reply")
+	is.NoErr(err)
+	is.Equal(e.ParentID, 1)
+}
+
+func TestDiscussionTopic_Actions(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	topic := &DiscussionTopic{ID: 5, ctxPath: "/courses/1", client: cli}
+	entry := &DiscussionEntry{ID: 1, topicPath: topic.path(), client: cli}
+
+	var method, path string
+	var query map[string][]string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		method, path, query = r.Method, r.URL.Path, r.URL.Query()
+		w.WriteHeader(http.StatusOK)
+	}
+	for _, p := range []string{"read", "read_all", "subscribed", "entries/1", "entries/1/read", "entries/1/rating"} {
+		mux.HandleFunc("/api/v1/courses/1/discussion_topics/5/"+p, handler)
+	}
+
+	is.NoErr(topic.MarkRead())
+	is.Equal(method, "PUT")
+	is.Equal(path, "/api/v1/courses/1/discussion_topics/5/read")
+	is.NoErr(topic.MarkAllUnread())
+	is.Equal(method, "DELETE")
+	is.Equal(path, "/api/v1/courses/1/discussion_topics/5/read_all")
+	is.NoErr(topic.Subscribe())
+	is.True(topic.Subscribed)
+	is.Equal(method, "PUT")
+	is.NoErr(topic.Unsubscribe())
+	is.True(!topic.Subscribed)
+	is.Equal(method, "DELETE")
+
+	is.NoErr(entry.MarkRead())
+	is.Equal(entry.ReadState, "read")
+	is.Equal(path, "/api/v1/courses/1/discussion_topics/5/entries/1/read")
+	is.NoErr(entry.Rate(1))
+	is.Equal(method, "POST")
+	is.Equal(query["rating"], []string{"1"})
+	is.NoErr(entry.Delete())
+	is.Equal(method, "DELETE")
+	is.Equal(path, "/api/v1/courses/1/discussion_topics/5/entries/1")
+}
+
+func TestDiscussionTopic_View(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	topic := &DiscussionTopic{ID: 5, ctxPath: "/courses/1", client: cli}
+
+	mux.HandleFunc("/api/v1/courses/1/discussion_topics/5/view", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "GET")
+		w.Write([]byte(`{
+			"unread_entries": [1, 3],
+			"forced_entries": [1],
+			"entry_ratings": {"3": 1},
+			"participants": [{"id": 10, "display_name": "user 1"}, {"id": 11, "display_name": "user 2"}],
+			"view": [
+				{"id": 1, "user_id": 10, "parent_id": null, "message": "top", "replies": [
+					{"id": 3, "user_id": 11, "parent_id": 1, "message": "reply", "replies": [
+						{"id": 4, "user_id": 10, "parent_id": 3, "message": "nested"}
+					]}
+				]},
+				{"id": 2, "deleted": true}
+			],
+			"new_entries": []
+		}`))
+	})
+
+	v, err := topic.View()
+	is.NoErr(err)
+	is.Equal(v.UnreadEntries, []int{1, 3})
+	is.Equal(v.EntryRatings[3], 1)
+	is.Equal(len(v.Participants), 2)
+	is.Equal(len(v.Entries), 2)
+	is.True(v.Entries[1].Deleted)
+
+	var ids, depths []int
+	is.NoErr(v.Walk(func(e *DiscussionEntry, depth int) error {
+		is.True(e.client != nil)
+		is.Equal(e.topicPath, "/courses/1/discussion_topics/5")
+		ids = append(ids, e.ID)
+		depths = append(depths, depth)
+		return nil
+	}))
+	is.Equal(ids, []int{1, 3, 4, 2})
+	is.Equal(depths, []int{0, 1, 2, 0})
+}
@@ -0,0 +1,345 @@
+package canvas
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Enrollment types used when enrolling a user.
+const (
+	StudentEnrollment  = "StudentEnrollment"
+	TeacherEnrollment  = "TeacherEnrollment"
+	TaEnrollment       = "TaEnrollment"
+	ObserverEnrollment = "ObserverEnrollment"
+	DesignerEnrollment = "DesignerEnrollment"
+)
+
+// Enrollment is an enrollment object
+// https://canvas.instructure.com/doc/api/enrollments.html
+type Enrollment struct {
+	ID                   int    `json:"id"`
+	CourseID             int    `json:"course_id"`
+	CourseIntegrationID  string `json:"course_integration_id"`
+	CourseSectionID      int    `json:"course_section_id"`
+	SectionIntegrationID string `json:"section_integration_id"`
+
+	EnrollmentState                string `json:"enrollment_state"`
+	Role                           string `json:"role"`
+	RoleID                         int    `json:"role_id"`
+	Type                           string `json:"type"`
+	LimitPrivilegesToCourseSection bool   `json:"limit_privileges_to_course_section"`
+	UserID                         int    `json:"user_id"`
+	User                           *User  `json:"user"`
+
+	SisCourseID      string      `json:"sis_course_id"`
+	SisAccountID     string      `json:"sis_account_id"`
+	SisSectionID     string      `json:"sis_section_id"`
+	SisUserID        string      `json:"sis_user_id"`
+	SisImportID      int         `json:"sis_import_id"`
+	RootAccountID    int         `json:"root_account_id"`
+	AssociatedUserID interface{} `json:"associated_user_id"`
+
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+	StartAt           time.Time `json:"start_at"`
+	EndAt             time.Time `json:"end_at"`
+	LastActivityAt    time.Time `json:"last_activity_at"`
+	LastAttendedAt    time.Time `json:"last_attended_at"`
+	TotalActivityTime int       `json:"total_activity_time"`
+
+	HTMLURL string `json:"html_url"`
+	Grades  struct {
+		HTMLURL              string  `json:"html_url"`
+		CurrentScore         float64 `json:"current_score"`
+		CurrentGrade         string  `json:"current_grade"`
+		FinalScore           float64 `json:"final_score"`
+		FinalGrade           string  `json:"final_grade"`
+		UnpostedCurrentGrade string  `json:"unposted_current_grade"`
+		UnpostedFinalGrade   string  `json:"unposted_final_grade"`
+		UnpostedCurrentScore string  `json:"unposted_current_score"`
+		UnpostedFinalScore   string  `json:"unposted_final_score"`
+	} `json:"grades"`
+	OverrideGrade                     string  `json:"override_grade"`
+	OverrideScore                     float64 `json:"override_score"`
+	UnpostedCurrentGrade              string  `json:"unposted_current_grade"`
+	UnpostedFinalGrade                string  `json:"unposted_final_grade"`
+	UnpostedCurrentScore              string  `json:"unposted_current_score"`
+	UnpostedFinalScore                string  `json:"unposted_final_score"`
+	HasGradingPeriods                 bool    `json:"has_grading_periods"`
+	TotalsForAllGradingPeriodsOption  bool    `json:"totals_for_all_grading_periods_option"`
+	CurrentGradingPeriodTitle         string  `json:"current_grading_period_title"`
+	CurrentGradingPeriodID            int     `json:"current_grading_period_id"`
+	CurrentPeriodOverrideGrade        string  `json:"current_period_override_grade"`
+	CurrentPeriodOverrideScore        float64 `json:"current_period_override_score"`
+	CurrentPeriodUnpostedCurrentScore float64 `json:"current_period_unposted_current_score"`
+	CurrentPeriodUnpostedFinalScore   float64 `json:"current_period_unposted_final_score"`
+	CurrentPeriodUnpostedCurrentGrade string  `json:"current_period_unposted_current_grade"`
+	CurrentPeriodUnpostedFinalGrade   string  `json:"current_period_unposted_final_grade"`
+
+	client doer
+}
+
+// WithContext returns a copy of the enrollment that uses ctx.
+func (e *Enrollment) WithContext(ctx context.Context) *Enrollment {
+	cp := *e
+	cp.client = withContext(e.client, ctx)
+	return &cp
+}
+
+// EnrollmentStateOpt filters enrollments by their state. The states
+// can be "active", "invited", "creation_pending", "deleted", "rejected",
+// "completed", "inactive", "current_and_invited", "current_and_future",
+// or "current_and_concluded".
+func EnrollmentStateOpt(states ...string) Option {
+	return ArrayOpt("state", states...)
+}
+
+// EnrollmentRoleOpt filters enrollments by the name of their role.
+func EnrollmentRoleOpt(roles ...string) Option {
+	return ArrayOpt("role", roles...)
+}
+
+// Enrollments returns a channel of the course's enrollments.
+//
+// https://canvas.instructure.com/doc/api/enrollments.html#method.enrollments_api.index
+func (c *Course) Enrollments(opts ...Option) <-chan *Enrollment {
+	return enrollmentsChannel(c.EnrollmentsIter(opts...), c.errorHandler)
+}
+
+// ListEnrollments returns a slice of the course's enrollments. The
+// enrollments can be filtered using OptStudent, OptTeacher, etc. as
+// well as EnrollmentStateOpt and EnrollmentRoleOpt.
+//
+// https://canvas.instructure.com/doc/api/enrollments.html#method.enrollments_api.index
+func (c *Course) ListEnrollments(opts ...Option) ([]*Enrollment, error) {
+	return collectEnrollments(c.EnrollmentsIter(opts...))
+}
+
+// EnrollmentsIter returns an iterator over the course's enrollments.
+//
+// https://canvas.instructure.com/doc/api/enrollments.html#method.enrollments_api.index
+func (c *Course) EnrollmentsIter(opts ...Option) *EnrollmentIterator {
+	return newEnrollmentIterator(c.client, c.id("/courses/%d/enrollments"), opts)
+}
+
+// Enroll will enroll a user in the course. The enrollment type should
+// be one of StudentEnrollment, TeacherEnrollment, TaEnrollment,
+// ObserverEnrollment, or DesignerEnrollment. Any options are sent
+// as enrollment parameters (ex. Opt("enrollment_state", "active")).
+//
+// https://canvas.instructure.com/doc/api/enrollments.html#method.enrollments_api.create
+func (c *Course) Enroll(userID int, enrollmentType string, opts ...Option) (*Enrollment, error) {
+	return enroll(c.client, c.id("/courses/%d/enrollments"), userID, enrollmentType, opts)
+}
+
+// Enrollments returns a channel of the user's enrollments.
+//
+// https://canvas.instructure.com/doc/api/enrollments.html#method.enrollments_api.index
+func (u *User) Enrollments(opts ...Option) <-chan *Enrollment {
+	return enrollmentsChannel(u.EnrollmentsIter(opts...), ConcurrentErrorHandler)
+}
+
+// ListEnrollments returns a slice of the user's enrollments.
+//
+// https://canvas.instructure.com/doc/api/enrollments.html#method.enrollments_api.index
+func (u *User) ListEnrollments(opts ...Option) ([]*Enrollment, error) {
+	return collectEnrollments(u.EnrollmentsIter(opts...))
+}
+
+// EnrollmentsIter returns an iterator over the user's enrollments.
+//
+// https://canvas.instructure.com/doc/api/enrollments.html#method.enrollments_api.index
+func (u *User) EnrollmentsIter(opts ...Option) *EnrollmentIterator {
+	return newEnrollmentIterator(u.client, u.id("/users/%d/enrollments"), opts)
+}
+
+// Enroll will enroll a user in the section.
+//
+// https://canvas.instructure.com/doc/api/enrollments.html#method.enrollments_api.create
+func (s *Section) Enroll(userID int, enrollmentType string, opts ...Option) (*Enrollment, error) {
+	return enroll(s.client, fmt.Sprintf("/sections/%d/enrollments", s.ID), userID, enrollmentType, opts)
+}
+
+func enroll(d doer, path string, userID int, enrollmentType string, opts []Option) (*Enrollment, error) {
+	q := params{
+		"enrollment[user_id]": {fmt.Sprintf("%d", userID)},
+		"enrollment[type]":    {enrollmentType},
+	}
+	q.Add(toPrefixedOpts("enrollment", opts))
+	resp, err := post(d, path, q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	e := &Enrollment{}
+	if err = json.NewDecoder(resp.Body).Decode(e); err != nil {
+		return nil, err
+	}
+	e.setclient(d)
+	return e, nil
+}
+
+// Conclude will conclude the enrollment.
+//
+// https://canvas.instructure.com/doc/api/enrollments.html#method.enrollments_api.destroy
+func (e *Enrollment) Conclude() error {
+	return e.task("conclude")
+}
+
+// Delete will delete the enrollment.
+//
+// https://canvas.instructure.com/doc/api/enrollments.html#method.enrollments_api.destroy
+func (e *Enrollment) Delete() error {
+	return e.task("delete")
+}
+
+// Deactivate will make the enrollment inactive.
+//
+// https://canvas.instructure.com/doc/api/enrollments.html#method.enrollments_api.destroy
+func (e *Enrollment) Deactivate() error {
+	return e.task("deactivate")
+}
+
+// Reactivate will reactivate an inactive enrollment.
+//
+// https://canvas.instructure.com/doc/api/enrollments.html#method.enrollments_api.reactivate
+func (e *Enrollment) Reactivate() error {
+	return e.send(put, e.path()+"/reactivate", nil)
+}
+
+// Accept will accept a course invitation for the current user.
+//
+// https://canvas.instructure.com/doc/api/enrollments.html#method.enrollments_api.accept
+func (e *Enrollment) Accept() error {
+	return e.respond("accept")
+}
+
+// Reject will reject a course invitation for the current user.
+//
+// https://canvas.instructure.com/doc/api/enrollments.html#method.enrollments_api.reject
+func (e *Enrollment) Reject() error {
+	return e.respond("reject")
+}
+
+func (e *Enrollment) task(task string) error {
+	return e.send(delete, e.path(), params{"task": {task}})
+}
+
+func (e *Enrollment) respond(action string) error {
+	resp, err := post(e.client, e.path()+"/"+action, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	var res struct {
+		Success bool `json:"success"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return err
+	}
+	if !res.Success {
+		return errors.New("could not " + action + " enrollment")
+	}
+	return nil
+}
+
+func (e *Enrollment) send(
+	method func(doer, string, encoder) (*http.Response, error),
+	path string,
+	q encoder,
+) error {
+	if err := send(e.client, method, path, q, e); err != nil {
+		return err
+	}
+	e.setclient(e.client)
+	return nil
+}
+
+func (e *Enrollment) path() string {
+	return fmt.Sprintf("/courses/%d/enrollments/%d", e.CourseID, e.ID)
+}
+
+func (e *Enrollment) setclient(d doer) {
+	e.client = d
+	if e.User != nil {
+		e.User.client = d
+	}
+}
+
+// EnrollmentIterator iterates over a paginated list of enrollments.
+type EnrollmentIterator struct{ *iterator }
+
+// Value returns the current enrollment.
+func (it *EnrollmentIterator) Value() *Enrollment {
+	e, _ := it.cur.(*Enrollment)
+	return e
+}
+
+func newEnrollmentIterator(d doer, path string, opts []Option) *EnrollmentIterator {
+	opts = enrollmentFilters(opts)
+	return &EnrollmentIterator{newIterator(d, path, opts, func(r io.Reader, emit emitFunc) error {
+		list := make([]*Enrollment, 0, defaultPerPage)
+		if err := json.NewDecoder(r).Decode(&list); err != nil {
+			return err
+		}
+		for _, e := range list {
+			e.setclient(d)
+			if err := emit(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	})}
+}
+
+func collectEnrollments(it *EnrollmentIterator) ([]*Enrollment, error) {
+	list := make([]*Enrollment, 0)
+	err := collect(it.iterator, &list)
+	return list, err
+}
+
+// enrollmentsChannel sends the enrollments from an iterator
+// over a channel and passes any errors to the error handler.
+func enrollmentsChannel(it *EnrollmentIterator, handler errorHandlerFunc) <-chan *Enrollment {
+	it.handler = handler
+	ch := make(chan *Enrollment)
+	stream(it.iterator, ch)
+	return ch
+}
+
+var enrollmentTypes = map[string]string{
+	"student":  StudentEnrollment,
+	"teacher":  TeacherEnrollment,
+	"ta":       TaEnrollment,
+	"observer": ObserverEnrollment,
+	"designer": DesignerEnrollment,
+}
+
+// enrollmentFilters converts the enrollment_type options used for
+// listing users (OptStudent, OptTeacher, etc.) into the type[]
+// parameter used by the enrollments endpoints.
+func enrollmentFilters(opts []Option) []Option {
+	var types []string
+	filtered := make([]Option, 0, len(opts))
+	for _, o := range opts {
+		if o.Name() != "enrollment_type" {
+			filtered = append(filtered, o)
+			continue
+		}
+		for _, v := range o.Value() {
+			if t, ok := enrollmentTypes[v]; ok {
+				v = t
+			}
+			types = append(types, v)
+		}
+	}
+	if len(types) == 0 {
+		return opts
+	}
+	return append(filtered, ArrayOpt("type", types...))
+}
@@ -0,0 +1,131 @@
+package canvas
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestCourse_Enrollments(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	c := &Course{ID: 1, client: cli}
+
+	var query map[string][]string
+	mux.HandleFunc("/api/v1/courses/1/enrollments", func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		switch r.Method {
+		case "GET":
+			handlePagingatedList(t, 2, "enrollment.json")(w, r)
+		case "POST":
+			writeTestFile(t, "enrollment.json", w)
+		default:
+			t.Errorf("wrong method %s", r.Method)
+		}
+	})
+
+	list, err := c.ListEnrollments(OptStudent, OptTeacher, EnrollmentStateOpt("active", "invited"))
+	is.NoErr(err)
+	is.Equal(len(list), 2)
+	is.Equal(query["type[]"], []string{"StudentEnrollment", "TeacherEnrollment"})
+	is.Equal(query["state[]"], []string{"active", "invited"})
+	is.Equal(len(query["enrollment_type"]), 0)
+	is.Equal(list[0].User.Name, "Sheldon Cooper")
+	is.True(list[0].client != nil)
+	is.True(list[0].User.client != nil)
+	n := 0
+	for e := range c.Enrollments() {
+		is.True(e.client != nil)
+		n++
+	}
+	is.Equal(n, 2)
+
+	e, err := c.Enroll(2, StudentEnrollment, Opt("enrollment_state", "active"), Opt("notify", true))
+	is.NoErr(err)
+	is.Equal(query["enrollment[user_id]"], []string{"2"})
+	is.Equal(query["enrollment[type]"], []string{"StudentEnrollment"})
+	is.Equal(query["enrollment[enrollment_state]"], []string{"active"})
+	is.Equal(query["enrollment[notify]"], []string{"true"})
+	is.Equal(e.ID, 8)
+	is.Equal(e.Grades.CurrentGrade, "A-")
+}
+
+func TestUser_Enrollments(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	u := &User{ID: 2, client: cli}
+	mux.HandleFunc("/api/v1/users/2/enrollments", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "GET")
+		handlePagingatedList(t, 3, "enrollment.json")(w, r)
+	})
+	list, err := u.ListEnrollments()
+	is.NoErr(err)
+	is.Equal(len(list), 3)
+	n := 0
+	for range u.Enrollments() {
+		n++
+	}
+	is.Equal(n, 3)
+}
+
+func TestEnrollment_Tasks(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	e := &Enrollment{ID: 8, CourseID: 1, client: cli}
+
+	var method, path string
+	var query map[string][]string
+	mux.HandleFunc("/api/v1/courses/1/enrollments/8", func(w http.ResponseWriter, r *http.Request) {
+		method, path, query = r.Method, r.URL.Path, r.URL.Query()
+		writeTestFile(t, "enrollment.json", w)
+	})
+	mux.HandleFunc("/api/v1/courses/1/enrollments/8/reactivate", func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		writeTestFile(t, "enrollment.json", w)
+	})
+	mux.HandleFunc("/api/v1/courses/1/enrollments/8/accept", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "POST")
+		w.Write([]byte(`{"success":true}`))
+	})
+	mux.HandleFunc("/api/v1/courses/1/enrollments/8/reject", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "POST")
+		w.Write([]byte(`{"success":false}`))
+	})
+
+	for task, fn := range map[string]func() error{
+		"conclude":   e.Conclude,
+		"delete":     e.Delete,
+		"deactivate": e.Deactivate,
+	} {
+		is.NoErr(fn())
+		is.Equal(method, "DELETE")
+		is.Equal(query["task"], []string{task})
+	}
+	is.Equal(e.EnrollmentState, "active")
+
+	is.NoErr(e.Reactivate())
+	is.Equal(method, "PUT")
+	is.Equal(path, "/api/v1/courses/1/enrollments/8/reactivate")
+
+	is.NoErr(e.Accept())
+	is.True(e.Reject() != nil)
+}
+
+func TestSection_Enroll(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	s := &Section{ID: 4, client: cli}
+	mux.HandleFunc("/api/v1/sections/4/enrollments", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "POST")
+		is.Equal(r.URL.Query().Get("enrollment[type]"), TaEnrollment)
+		writeTestFile(t, "enrollment.json", w)
+	})
+	e, err := s.Enroll(2, TaEnrollment)
+	is.NoErr(err)
+	is.Equal(e.CourseSectionID, 4)
+}
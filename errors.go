@@ -0,0 +1,326 @@
+package canvas
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// These errors can be compared to an *APIError using errors.Is.
+var (
+	// ErrNotFound is matched by 404 Not Found responses.
+	ErrNotFound = errors.New("canvas: not found")
+	// ErrUnauthorized is matched by 401 Unauthorized responses.
+	ErrUnauthorized = errors.New("canvas: unauthorized")
+	// ErrForbidden is matched by 403 Forbidden responses that
+	// were not caused by the rate limit.
+	ErrForbidden = errors.New("canvas: forbidden")
+	// ErrThrottled is matched by responses that were
+	// rejected because the rate limit was exceeded.
+	ErrThrottled = errors.New("canvas: rate limit exceeded")
+	// ErrValidation is matched by responses that have
+	// validation errors for the parameters that were sent.
+	ErrValidation = errors.New("canvas: validation failed")
+
+	// ErrRateLimitExceeded is returned when the api rate limit has been reached.
+	//
+	// Deprecated: use ErrThrottled
+	ErrRateLimitExceeded = ErrThrottled
+)
+
+// IsRateLimit returns true if the error
+// given is a rate limit error.
+func IsRateLimit(e error) bool {
+	return errors.Is(e, ErrThrottled)
+}
+
+// APIError is an error response from the canvas api.
+//
+// https://canvas.instructure.com/doc/api/file.throttling.html
+type APIError struct {
+	StatusCode int
+	Status     string
+	Method     string
+	URL        string
+	// RequestID is the X-Request-Context-Id
+	// header sent back by canvas.
+	RequestID string
+	// RetryAfter is taken from the Retry-After header
+	// and is zero if the header was not sent.
+	RetryAfter time.Duration
+
+	// Message is the top level error message.
+	Message string
+	// Errors are the general error messages.
+	Errors []ErrorMessage
+	// FieldErrors are validation errors for specific
+	// parameters, keyed by the parameter name.
+	FieldErrors map[string][]FieldError
+	// ErrorReportID is the id of the error
+	// report that canvas made for server errors.
+	ErrorReportID int
+
+	throttled bool
+}
+
+// ErrorMessage is one of the error messages in an error response.
+type ErrorMessage struct {
+	Message   string `json:"message"`
+	ErrorCode string `json:"error_code,omitempty"`
+}
+
+// FieldError is a validation error for one parameter.
+type FieldError struct {
+	Attribute string `json:"attribute"`
+	Type      string `json:"type"`
+	Message   string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	if e.Method != "" {
+		b.WriteString(e.Method)
+		b.WriteByte(' ')
+	}
+	if e.URL != "" {
+		b.WriteString(e.URL)
+		b.WriteString(": ")
+	}
+	b.WriteString(e.Status)
+	msgs := e.messages()
+	if len(msgs) > 0 {
+		b.WriteString(": ")
+		b.WriteString(strings.Join(msgs, ", "))
+	}
+	return b.String()
+}
+
+func (e *APIError) messages() []string {
+	msgs := make([]string, 0, len(e.Errors)+len(e.FieldErrors)+1)
+	if e.Message != "" {
+		msgs = append(msgs, e.Message)
+	}
+	for _, m := range e.Errors {
+		msgs = append(msgs, m.Message)
+	}
+	fields := make([]string, 0, len(e.FieldErrors))
+	for name := range e.FieldErrors {
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	for _, name := range fields {
+		for _, fe := range e.FieldErrors[name] {
+			msgs = append(msgs, fmt.Sprintf("%s: %s", name, fe.Message))
+		}
+	}
+	return msgs
+}
+
+// Is allows an APIError to be compared with the
+// sentinel errors using errors.Is.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden && !e.throttled
+	case ErrThrottled:
+		return e.throttled
+	case ErrValidation:
+		return e.StatusCode == http.StatusUnprocessableEntity || len(e.FieldErrors) > 0
+	}
+	return false
+}
+
+// As allows an APIError to be used with errors.As and the
+// deprecated Error and AuthError types.
+func (e *APIError) As(target interface{}) bool {
+	switch t := target.(type) {
+	case **AuthError:
+		if e.StatusCode != http.StatusNotFound && e.StatusCode != http.StatusUnauthorized {
+			return false
+		}
+		ae := &AuthError{Status: e.Status}
+		for _, m := range e.Errors {
+			ae.Errors = append(ae.Errors, errorMsg{Message: m.Message})
+		}
+		*t = ae
+		return true
+	case **Error:
+		if e.StatusCode == http.StatusNotFound || e.StatusCode == http.StatusUnauthorized {
+			return false
+		}
+		err := &Error{Message: e.Message, Status: e.Status}
+		if fe := e.FieldErrors["end_date"]; len(fe) > 0 {
+			err.Errors.EndDate = fe[0].Message
+		}
+		*t = err
+		return true
+	}
+	return false
+}
+
+// Error is an error response.
+//
+// Deprecated: errors returned by the api are *APIError values. Error
+// is kept so that errors.As(err, &canvasErr) continues to work.
+type Error struct {
+	Errors struct {
+		EndDate string `json:"end_date"`
+	} `json:"errors"`
+	Message string `json:"message"`
+
+	Err      string `json:"error"`
+	SentryID string `json:"sentryId"`
+
+	Status string `json:"-"`
+}
+
+func (e *Error) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	if e.Errors.EndDate != "" {
+		return fmt.Sprintf("end_date: %s", e.Errors.EndDate)
+	}
+	if e.SentryID != "" {
+		return fmt.Sprintf("error status: %s; sentryId: %s", e.Err, e.SentryID)
+	}
+	return fmt.Sprintf("canvas error: %#v", e)
+}
+
+// AuthError is an authentication error response from canvas.
+//
+// Deprecated: errors returned by the api are *APIError values. Use
+// errors.Is(err, ErrUnauthorized) or errors.Is(err, ErrNotFound).
+type AuthError struct {
+	Status string     `json:"status"`
+	Errors []errorMsg `json:"errors"`
+}
+
+func (ae *AuthError) Error() string {
+	if ae.Status == "" {
+		return checkErrors(ae.Errors)
+	}
+	return fmt.Sprintf("%s: %s", ae.Status, checkErrors(ae.Errors))
+}
+
+type errorMsg struct {
+	Message string `json:"message,omitempty"`
+}
+
+func checkErrors(errs []errorMsg) string {
+	if len(errs) < 1 {
+		return ""
+	}
+	msgs := make([]string, len(errs))
+	for i := 0; i < len(errs); i++ {
+		msgs[i] = errs[i].Message
+	}
+	return strings.Join(msgs, ", ")
+}
+
+// maximum number of bytes read from an error response
+const maxErrorBody = 1 << 20
+
+func newAPIError(resp *http.Response) *APIError {
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		RequestID:  resp.Header.Get("X-Request-Context-Id"),
+		throttled:  isThrottled(resp),
+	}
+	if e.Status == "" {
+		e.Status = fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	if req := resp.Request; req != nil {
+		e.Method = req.Method
+		if req.URL != nil {
+			e.URL = req.URL.Path
+		}
+	}
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+		e.RetryAfter = time.Duration(secs) * time.Second
+	}
+
+	b, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	if err != nil {
+		return e
+	}
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 {
+		return e
+	}
+	var body struct {
+		Errors        json.RawMessage `json:"errors"`
+		Message       string          `json:"message"`
+		Err           string          `json:"error"`
+		ErrorReportID int             `json:"error_report_id"`
+	}
+	if err = json.Unmarshal(b, &body); err != nil {
+		// canvas sends plain text for some errors
+		// ex. "403 Forbidden (Rate Limit Exceeded)"
+		e.Message = string(b)
+		return e
+	}
+	e.Message = body.Message
+	if e.Message == "" {
+		e.Message = body.Err
+	}
+	e.ErrorReportID = body.ErrorReportID
+	e.parseErrors(body.Errors)
+	return e
+}
+
+// parseErrors handles the different forms that the "errors" field can
+// take. It is either a list of messages or an object that maps
+// parameter names to validation errors.
+func (e *APIError) parseErrors(raw json.RawMessage) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return
+	}
+	switch raw[0] {
+	case '[':
+		json.Unmarshal(raw, &e.Errors)
+	case '{':
+		fields := make(map[string]json.RawMessage)
+		if json.Unmarshal(raw, &fields) != nil {
+			return
+		}
+		e.FieldErrors = make(map[string][]FieldError, len(fields))
+		for name, val := range fields {
+			e.FieldErrors[name] = parseFieldErrors(name, val)
+		}
+	}
+}
+
+func parseFieldErrors(name string, raw json.RawMessage) []FieldError {
+	var list []FieldError
+	if json.Unmarshal(raw, &list) == nil {
+		return list
+	}
+	var msg string
+	if json.Unmarshal(raw, &msg) == nil {
+		return []FieldError{{Attribute: name, Message: msg}}
+	}
+	var msgs []string
+	if json.Unmarshal(raw, &msgs) == nil {
+		list = make([]FieldError, len(msgs))
+		for i, m := range msgs {
+			list[i] = FieldError{Attribute: name, Message: m}
+		}
+		return list
+	}
+	return []FieldError{{Attribute: name, Message: string(raw)}}
+}
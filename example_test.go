@@ -1,6 +1,7 @@
 package canvas_test
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -17,7 +18,7 @@ func Example_concurrentErrorHandling() {
 	canvas.SetToken("bad token")
 	reset := canvas.ConcurrentErrorHandler
 	canvas.ConcurrentErrorHandler = func(e error) error {
-		if _, ok := e.(*canvas.Error); !ok {
+		if errors.Is(e, canvas.ErrUnauthorized) {
 			failed = true
 			err = e
 			return e // non-nil will stop all goroutines
@@ -40,7 +41,7 @@ func Example_concurrentErrorHandling() {
 	fmt.Println(failed)
 
 	// Output:
-	// Invalid access token.
+	// GET /api/v1/users/self/files: 401 Unauthorized: Invalid access token.
 	// true
 
 	canvas.SetToken(os.Getenv("CANVAS_TEST_TOKEN"))
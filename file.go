@@ -2,6 +2,7 @@ package canvas
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -81,6 +82,13 @@ type File struct {
 	folder *Folder
 }
 
+// WithContext returns a copy of the file that uses ctx.
+func (f *File) WithContext(ctx context.Context) *File {
+	cp := *f
+	cp.client = withContext(f.client, ctx)
+	return &cp
+}
+
 // Name returns the file's filename
 func (f *File) Name() string {
 	return f.DisplayName
@@ -200,7 +208,7 @@ func (f *File) edit(opts ...Option) error {
 
 // WriteTo will write the contents of the file to an io.Writer
 func (f *File) WriteTo(w io.Writer) (int64, error) {
-	resp, err := http.Get(f.URL)
+	resp, err := f.download()
 	if err != nil {
 		return 0, err
 	}
@@ -269,13 +277,22 @@ func (fw *fileWriter) Close() error {
 //
 // This function will make an http request to get the data
 func (f *File) AsReadCloser() (io.ReadCloser, error) {
-	resp, err := http.Get(f.URL)
+	resp, err := f.download()
 	if err != nil {
 		return nil, err
 	}
 	return resp.Body, nil
 }
 
+// download will request the file's contents from the file's url.
+func (f *File) download() (*http.Response, error) {
+	req, err := http.NewRequestWithContext(contextOf(f.client), "GET", f.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	return downloadClient(f.client).Do(req)
+}
+
 // JoinFileObjs will join a file channel and a folder channel into a generic
 // file objects channel.
 func JoinFileObjs(files <-chan *File, folders <-chan *Folder) <-chan FileObj {
@@ -336,6 +353,13 @@ type Folder struct {
 	parent *Folder
 }
 
+// WithContext returns a copy of the folder that uses ctx.
+func (f *Folder) WithContext(ctx context.Context) *Folder {
+	cp := *f
+	cp.client = withContext(f.client, ctx)
+	return &cp
+}
+
 // Name returns only the folder's name without the path.
 func (f *Folder) Name() string {
 	return f.Foldername
@@ -380,10 +404,13 @@ func (f *Folder) File(id int, opts ...Option) (*File, error) {
 // in the folder.
 // https://canvas.instructure.com/doc/api/files.html#method.files.api_index
 func (f *Folder) Files(opts ...Option) <-chan *File {
-	return filesChannel(
-		f.client, fmt.Sprintf("folders/%d/files", f.ID),
-		ConcurrentErrorHandler, opts, f,
-	)
+	return filesChannel(f.FilesIter(opts...), ConcurrentErrorHandler)
+}
+
+// FilesIter returns an iterator over all of the files in the folder.
+// https://canvas.instructure.com/doc/api/files.html#method.files.api_index
+func (f *Folder) FilesIter(opts ...Option) *FileIterator {
+	return newFileIterator(f.client, fmt.Sprintf("folders/%d/files", f.ID), f, opts)
 }
 
 // ListFiles will list all of the files that are in the folder.
@@ -394,14 +421,13 @@ func (f *Folder) ListFiles(opts ...Option) ([]*File, error) {
 // Folders will return a channel that sends all of the sub-folders.
 // https://canvas.instructure.com/doc/api/files.html#method.folders.api_index
 func (f *Folder) Folders(opts ...Option) <-chan *Folder {
-	ch := make(folderChan)
-	pages := newPaginatedList(
-		f.client,
-		fmt.Sprintf("folders/%d/folders", f.ID),
-		sendFoldersFunc(f.client, ch, f), opts,
-	)
-	go handleErrs(pages.start(), ch, ConcurrentErrorHandler)
-	return ch
+	return foldersChannel(f.FoldersIter(opts...), ConcurrentErrorHandler)
+}
+
+// FoldersIter returns an iterator over all of the sub-folders.
+// https://canvas.instructure.com/doc/api/files.html#method.folders.api_index
+func (f *Folder) FoldersIter(opts ...Option) *FolderIterator {
+	return newFolderIterator(f.client, fmt.Sprintf("folders/%d/folders", f.ID), f, opts)
 }
 
 // ListFolders will collect all the folders in a slice of Folders.
@@ -497,31 +523,73 @@ func (f *Folder) edit(opts ...Option) error {
 	return json.NewDecoder(resp.Body).Decode(f)
 }
 
-func filesChannel(
-	d doer,
-	path string,
-	handler errorHandlerFunc,
-	opts []Option,
-	parent *Folder,
-) <-chan *File {
-	ch := make(fileChan)
-	pager := newPaginatedList(d, path, sendFilesFunc(d, ch, parent), opts)
-	go handleErrs(pager.start(), ch, handler)
+// FileIterator iterates over a paginated list of files.
+type FileIterator struct{ *iterator }
+
+// Value returns the current file.
+func (it *FileIterator) Value() *File {
+	f, _ := it.cur.(*File)
+	return f
+}
+
+// FolderIterator iterates over a paginated list of folders.
+type FolderIterator struct{ *iterator }
+
+// Value returns the current folder.
+func (it *FolderIterator) Value() *Folder {
+	f, _ := it.cur.(*Folder)
+	return f
+}
+
+func newFileIterator(d doer, path string, parent *Folder, opts []Option) *FileIterator {
+	return &FileIterator{newIterator(d, path, opts, func(r io.Reader, emit emitFunc) error {
+		files := make([]*File, 0, defaultPerPage)
+		if err := json.NewDecoder(r).Decode(&files); err != nil {
+			return err
+		}
+		for _, f := range files {
+			f.setclient(d)
+			f.folder = parent
+			if err := emit(f); err != nil {
+				return err
+			}
+		}
+		return nil
+	})}
+}
+
+func newFolderIterator(d doer, path string, parent *Folder, opts []Option) *FolderIterator {
+	return &FolderIterator{newIterator(d, path, opts, func(r io.Reader, emit emitFunc) error {
+		folders := make([]*Folder, 0, defaultPerPage)
+		if err := json.NewDecoder(r).Decode(&folders); err != nil {
+			return err
+		}
+		for _, f := range folders {
+			f.setclient(d)
+			f.parent = parent
+			if err := emit(f); err != nil {
+				return err
+			}
+		}
+		return nil
+	})}
+}
+
+// filesChannel sends the files from an iterator over a channel
+// and passes any errors to the error handler.
+func filesChannel(it *FileIterator, handler errorHandlerFunc) <-chan *File {
+	it.handler = handler
+	ch := make(chan *File)
+	stream(it.iterator, ch)
 	return ch
 }
 
-func foldersChannel(
-	d doer,
-	path string,
-	handler errorHandlerFunc,
-	opts []Option,
-	parent *Folder,
-) <-chan *Folder {
-	ch := make(folderChan)
-	pages := newPaginatedList(
-		d, path, sendFoldersFunc(d, ch, parent), opts,
-	)
-	go handleErrs(pages.start(), ch, ConcurrentErrorHandler)
+// foldersChannel sends the folders from an iterator over a
+// channel and passes any errors to the error handler.
+func foldersChannel(it *FolderIterator, handler errorHandlerFunc) <-chan *Folder {
+	it.handler = handler
+	ch := make(chan *Folder)
+	stream(it.iterator, ch)
 	return ch
 }
 
@@ -705,13 +773,17 @@ func (f *fileupload) upload(d doer, filename string, r io.Reader) (*File, error)
 		return nil, err
 	}
 	f.writer.Close() // do not defer, adds the correct line endings to the body
+	body := f.body.Bytes()
 	req := &http.Request{
 		Method: "POST",
 		URL:    f.url,
-		Body:   ioutil.NopCloser(f.body),
+		Body:   ioutil.NopCloser(bytes.NewReader(body)),
+		GetBody: func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(body)), nil
+		},
 		Header: http.Header{
 			"Content-Type": {f.writer.FormDataContentType()}},
-		ContentLength: int64(f.body.Len()),
+		ContentLength: int64(len(body)),
 	}
 	resp, err := do(d, req)
 	if err != nil {
@@ -723,73 +795,26 @@ func (f *fileupload) upload(d doer, filename string, r io.Reader) (*File, error)
 }
 
 func listFiles(d doer, path string, parent *Folder, opts []Option) ([]*File, error) {
-	if opts == nil {
-		opts = []Option{}
-	}
-	var (
-		page     = 1
-		perpage  = 10
-		files    []*File
-		tmpfiles []*File = make([]*File, 10)
-	)
-	p := params{
-		"page":     {strconv.Itoa(page)},
-		"per_page": {strconv.Itoa(perpage)},
-	}
-	p.Add(opts)
-	resp, err := get(d, path, p)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-	n, err := findlastpage(resp.Header)
-	if err != nil {
-		return nil, err
-	}
-	files = make([]*File, 0, n*perpage)
-
-	if err := json.NewDecoder(resp.Body).Decode(&tmpfiles); err != nil {
-		return nil, err
-	}
-	files = append(files, tmpfiles...)
-
-	for page = 2; page <= n; page++ {
-		p := params{
-			"page":     {strconv.Itoa(page)},
-			"per_page": {strconv.Itoa(perpage)},
+	files := make([]*File, 0)
+	err := getList(d, func(r io.Reader) error {
+		tmpfiles := make([]*File, 0)
+		if err := json.NewDecoder(r).Decode(&tmpfiles); err != nil {
+			return err
 		}
-		p.Add(opts)
-		resp, err = get(d, path, p)
-		if err != nil {
-			return files, err
-		}
-		if err = json.NewDecoder(resp.Body).Decode(&tmpfiles); err != nil {
-			resp.Body.Close()
-			return files, err
+		for _, f := range tmpfiles {
+			f.client = d
 		}
 		files = append(files, tmpfiles...)
-		resp.Body.Close()
-	}
-	for i := range files {
-		files[i].client = d
-	}
-	return files, nil
+		return nil
+	}, path, opts)
+	return files, err
 }
 
 func listFolders(d doer, path string, parent *Folder, opts []Option) ([]*Folder, error) {
-	ch := make(chan *Folder)
-	page := newPaginatedList(d, path, sendFoldersFunc(d, ch, nil), opts)
+	it := newFolderIterator(d, path, parent, opts)
 	folders := make([]*Folder, 0)
-	errs := page.start()
-	for {
-		select {
-		case folder := <-ch:
-			folders = append(folders, folder)
-		case err := <-errs:
-			close(ch)
-			return folders, err
-		}
-	}
+	err := collect(it.iterator, &folders)
+	return folders, err
 }
 
 func folderList(d doer, path string) ([]*Folder, error) {
@@ -818,13 +843,3 @@ func (f *File) setclient(d doer) {
 func (f *Folder) setclient(d doer) {
 	f.client = d
 }
-
-type (
-	fileChan   chan *File
-	folderChan chan *Folder
-	courseChan chan *Course
-)
-
-func (fc fileChan) Close()   { close(fc) }
-func (fc folderChan) Close() { close(fc) }
-func (cc courseChan) Close() { close(cc) }
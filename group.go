@@ -0,0 +1,643 @@
+package canvas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"path/filepath"
+
+	"github.com/harrybrwn/go-querystring/query"
+)
+
+// Group is a group of users in a course or account.
+//
+// https://canvas.instructure.com/doc/api/groups.html
+type Group struct {
+	ID          int    `json:"id" url:"-"`
+	Name        string `json:"name" url:"name,omitempty"`
+	Description string `json:"description" url:"description,omitempty"`
+	IsPublic    bool   `json:"is_public" url:"is_public,omitempty"`
+	// JoinLevel can be any of:
+	//	- "parent_context_auto_join"
+	//	- "parent_context_request"
+	//	- "invitation_only"
+	JoinLevel       string `json:"join_level" url:"join_level,omitempty"`
+	FollowedByUser  bool   `json:"followed_by_user" url:"-"`
+	MembersCount    int    `json:"members_count" url:"-"`
+	AvatarURL       string `json:"avatar_url" url:"-"`
+	ContextType     string `json:"context_type" url:"-"`
+	CourseID        int    `json:"course_id" url:"-"`
+	AccountID       int    `json:"account_id" url:"-"`
+	Role            string `json:"role" url:"-"`
+	GroupCategoryID int    `json:"group_category_id" url:"-"`
+	SisGroupID      string `json:"sis_group_id" url:"sis_group_id,omitempty"`
+	SisImportID     int    `json:"sis_import_id" url:"-"`
+	StorageQuotaMb  int    `json:"storage_quota_mb" url:"storage_quota_mb,omitempty"`
+	Permissions     struct {
+		CreateDiscussionTopic bool `json:"create_discussion_topic"`
+		CreateAnnouncement    bool `json:"create_announcement"`
+	} `json:"permissions" url:"-"`
+	Users []*User `json:"users" url:"-"`
+
+	client doer
+}
+
+// GroupCategory is a set of groups in a course or account.
+//
+// https://canvas.instructure.com/doc/api/group_categories.html
+type GroupCategory struct {
+	ID   int    `json:"id" url:"-"`
+	Name string `json:"name" url:"name,omitempty"`
+	Role string `json:"role" url:"-"`
+	// SelfSignup can be "enabled", "restricted", or empty
+	// if students cannot sign up for groups themselves.
+	SelfSignup string `json:"self_signup" url:"self_signup,omitempty"`
+	// AutoLeader can be "first", "random", or empty
+	// if groups do not get a leader.
+	AutoLeader         string    `json:"auto_leader" url:"auto_leader,omitempty"`
+	ContextType        string    `json:"context_type" url:"-"`
+	CourseID           int       `json:"course_id" url:"-"`
+	AccountID          int       `json:"account_id" url:"-"`
+	GroupLimit         int       `json:"group_limit" url:"group_limit,omitempty"`
+	SisGroupCategoryID string    `json:"sis_group_category_id" url:"sis_group_category_id,omitempty"`
+	SisImportID        int       `json:"sis_import_id" url:"-"`
+	Progress           *Progress `json:"progress" url:"-"`
+
+	client doer
+}
+
+// GroupMembership is a user's membership in a group.
+//
+// https://canvas.instructure.com/doc/api/groups.html#GroupMembership
+type GroupMembership struct {
+	ID      int `json:"id"`
+	GroupID int `json:"group_id"`
+	UserID  int `json:"user_id"`
+	// WorkflowState can be "accepted", "invited", or "requested".
+	WorkflowState string `json:"workflow_state"`
+	Moderator     bool   `json:"moderator"`
+	JustCreated   bool   `json:"just_created"`
+	SisImportID   int    `json:"sis_import_id"`
+}
+
+// WithContext returns a copy of the group that uses ctx.
+func (g *Group) WithContext(ctx context.Context) *Group {
+	cp := *g
+	cp.client = withContext(g.client, ctx)
+	return &cp
+}
+
+// WithContext returns a copy of the group category that uses ctx.
+func (gc *GroupCategory) WithContext(ctx context.Context) *GroupCategory {
+	cp := *gc
+	cp.client = withContext(gc.client, ctx)
+	return &cp
+}
+
+// GetGroup will get a group given its id.
+func GetGroup(id int, opts ...Option) (*Group, error) { return ca.GetGroup(id, opts...) }
+
+// GetGroup will get a group given its id.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.groups.show
+func (c *Canvas) GetGroup(id int, opts ...Option) (*Group, error) {
+	return getGroup(c.client, id, opts)
+}
+
+// GetGroupCategory will get a group category given its id.
+func GetGroupCategory(id int, opts ...Option) (*GroupCategory, error) {
+	return ca.GetGroupCategory(id, opts...)
+}
+
+// GetGroupCategory will get a group category given its id.
+//
+// https://canvas.instructure.com/doc/api/group_categories.html#method.group_categories.show
+func (c *Canvas) GetGroupCategory(id int, opts ...Option) (*GroupCategory, error) {
+	gc := &GroupCategory{}
+	if err := getjson(c.client, gc, optEnc(opts), "/group_categories/%d", id); err != nil {
+		return nil, err
+	}
+	gc.setclient(c.client)
+	return gc, nil
+}
+
+// Groups returns a channel of the groups in the course.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.groups.context_index
+func (c *Course) Groups(opts ...Option) <-chan *Group {
+	return groupsChannel(c.GroupsIter(opts...), c.errorHandler)
+}
+
+// GroupsIter returns an iterator over the groups in the course.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.groups.context_index
+func (c *Course) GroupsIter(opts ...Option) *GroupIterator {
+	return newGroupIterator(c.client, c.id("/courses/%d/groups"), opts)
+}
+
+// ListGroups returns a slice of the groups in the course.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.groups.context_index
+func (c *Course) ListGroups(opts ...Option) ([]*Group, error) {
+	return collectGroups(c.GroupsIter(opts...))
+}
+
+// GroupCategories returns a slice of the course's group categories.
+//
+// https://canvas.instructure.com/doc/api/group_categories.html#method.group_categories.index
+func (c *Course) GroupCategories(opts ...Option) ([]*GroupCategory, error) {
+	return collectGroupCategories(c.GroupCategoriesIter(opts...))
+}
+
+// GroupCategoriesIter returns an iterator over the course's group categories.
+//
+// https://canvas.instructure.com/doc/api/group_categories.html#method.group_categories.index
+func (c *Course) GroupCategoriesIter(opts ...Option) *GroupCategoryIterator {
+	return newGroupCategoryIterator(c.client, c.id("/courses/%d/group_categories"), opts)
+}
+
+// CreateGroupCategory will create a group category in the course.
+// The create_group_count option can be used to make groups for the
+// category when it is created.
+//
+// https://canvas.instructure.com/doc/api/group_categories.html#method.group_categories.create
+func (c *Course) CreateGroupCategory(gc GroupCategory, opts ...Option) (*GroupCategory, error) {
+	return createGroupCategory(c.client, c.id("/courses/%d/group_categories"), gc, opts)
+}
+
+// Groups returns a channel of the groups in the account.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.groups.context_index
+func (a *Account) Groups(opts ...Option) <-chan *Group {
+	return groupsChannel(a.GroupsIter(opts...), ConcurrentErrorHandler)
+}
+
+// GroupsIter returns an iterator over the groups in the account.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.groups.context_index
+func (a *Account) GroupsIter(opts ...Option) *GroupIterator {
+	return newGroupIterator(a.cli, fmt.Sprintf("/accounts/%d/groups", a.ID), opts)
+}
+
+// ListGroups returns a slice of the groups in the account.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.groups.context_index
+func (a *Account) ListGroups(opts ...Option) ([]*Group, error) {
+	return collectGroups(a.GroupsIter(opts...))
+}
+
+// GroupCategories returns a slice of the account's group categories.
+//
+// https://canvas.instructure.com/doc/api/group_categories.html#method.group_categories.index
+func (a *Account) GroupCategories(opts ...Option) ([]*GroupCategory, error) {
+	return collectGroupCategories(a.GroupCategoriesIter(opts...))
+}
+
+// GroupCategoriesIter returns an iterator over the account's group categories.
+//
+// https://canvas.instructure.com/doc/api/group_categories.html#method.group_categories.index
+func (a *Account) GroupCategoriesIter(opts ...Option) *GroupCategoryIterator {
+	return newGroupCategoryIterator(a.cli, fmt.Sprintf("/accounts/%d/group_categories", a.ID), opts)
+}
+
+// CreateGroupCategory will create a group category in the account.
+//
+// https://canvas.instructure.com/doc/api/group_categories.html#method.group_categories.create
+func (a *Account) CreateGroupCategory(gc GroupCategory, opts ...Option) (*GroupCategory, error) {
+	return createGroupCategory(a.cli, fmt.Sprintf("/accounts/%d/group_categories", a.ID), gc, opts)
+}
+
+// Groups returns a channel of the current user's groups. Canvas
+// only lists groups for the current user.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.groups.index
+func (u *User) Groups(opts ...Option) <-chan *Group {
+	return groupsChannel(u.GroupsIter(opts...), ConcurrentErrorHandler)
+}
+
+// GroupsIter returns an iterator over the current user's groups.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.groups.index
+func (u *User) GroupsIter(opts ...Option) *GroupIterator {
+	return newGroupIterator(u.client, "/users/self/groups", opts)
+}
+
+// ListGroups returns a slice of the current user's groups.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.groups.index
+func (u *User) ListGroups(opts ...Option) ([]*Group, error) {
+	return collectGroups(u.GroupsIter(opts...))
+}
+
+// Update will send the category's fields to canvas
+// and replace them with the updated category.
+//
+// https://canvas.instructure.com/doc/api/group_categories.html#method.group_categories.update
+func (gc *GroupCategory) Update(opts ...Option) error {
+	q, err := query.Values(gc)
+	if err != nil {
+		return err
+	}
+	params(q).Add(opts)
+	return gc.send(put, fmt.Sprintf("/group_categories/%d", gc.ID), q)
+}
+
+// Delete will delete the group category.
+//
+// https://canvas.instructure.com/doc/api/group_categories.html#method.group_categories.destroy
+func (gc *GroupCategory) Delete() error {
+	return gc.send(delete, fmt.Sprintf("/group_categories/%d", gc.ID), nil)
+}
+
+func (gc *GroupCategory) send(
+	method func(doer, string, encoder) (*http.Response, error),
+	path string,
+	q encoder,
+) error {
+	if err := send(gc.client, method, path, q, gc); err != nil {
+		return err
+	}
+	gc.setclient(gc.client)
+	return nil
+}
+
+func (gc *GroupCategory) setclient(d doer) {
+	gc.client = d
+	if gc.Progress != nil {
+		gc.Progress.client = d
+	}
+}
+
+// GroupsIter returns an iterator over the groups in the category.
+//
+// https://canvas.instructure.com/doc/api/group_categories.html#method.group_categories.groups
+func (gc *GroupCategory) GroupsIter(opts ...Option) *GroupIterator {
+	return newGroupIterator(gc.client, fmt.Sprintf("/group_categories/%d/groups", gc.ID), opts)
+}
+
+// ListGroups returns a slice of the groups in the category.
+//
+// https://canvas.instructure.com/doc/api/group_categories.html#method.group_categories.groups
+func (gc *GroupCategory) ListGroups(opts ...Option) ([]*Group, error) {
+	return collectGroups(gc.GroupsIter(opts...))
+}
+
+// UsersIter returns an iterator over the users in the category. Use
+// Opt("unassigned", true) to only get users that are not in a group.
+//
+// https://canvas.instructure.com/doc/api/group_categories.html#method.group_categories.users
+func (gc *GroupCategory) UsersIter(opts ...Option) *UserIterator {
+	return newUserIterator(gc.client, fmt.Sprintf("/group_categories/%d/users", gc.ID), opts)
+}
+
+// ListUsers returns a slice of the users in the category.
+//
+// https://canvas.instructure.com/doc/api/group_categories.html#method.group_categories.users
+func (gc *GroupCategory) ListUsers(opts ...Option) ([]*User, error) {
+	return collectUsers(gc.UsersIter(opts...))
+}
+
+// CreateGroup will create a new group in the category.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.groups.create
+func (gc *GroupCategory) CreateGroup(g Group, opts ...Option) (*Group, error) {
+	q, err := query.Values(&g)
+	if err != nil {
+		return nil, err
+	}
+	params(q).Add(opts)
+	resp, err := post(gc.client, fmt.Sprintf("/group_categories/%d/groups", gc.ID), q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	group := &Group{}
+	if err = json.NewDecoder(resp.Body).Decode(group); err != nil {
+		return nil, err
+	}
+	group.setclient(gc.client)
+	return group, nil
+}
+
+// AssignUnassignedMembers will put all of the users in the category
+// that are not in a group into one of the category's groups. Canvas
+// does this in the background and the returned Progress can be used
+// to wait for it to finish.
+//
+// https://canvas.instructure.com/doc/api/group_categories.html#method.group_categories.assign_unassigned_members
+func (gc *GroupCategory) AssignUnassignedMembers(opts ...Option) (*Progress, error) {
+	resp, err := post(
+		gc.client,
+		fmt.Sprintf("/group_categories/%d/assign_unassigned_members", gc.ID),
+		optEnc(opts),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	p := &Progress{client: gc.client}
+	return p, json.NewDecoder(resp.Body).Decode(p)
+}
+
+// Update will send the group's fields to canvas
+// and replace them with the updated group.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.groups.update
+func (g *Group) Update(opts ...Option) error {
+	q, err := query.Values(g)
+	if err != nil {
+		return err
+	}
+	params(q).Add(opts)
+	return g.send(put, g.id("/groups/%d"), q)
+}
+
+// Delete will delete the group.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.groups.destroy
+func (g *Group) Delete() error {
+	return g.send(delete, g.id("/groups/%d"), nil)
+}
+
+func (g *Group) send(
+	method func(doer, string, encoder) (*http.Response, error),
+	path string,
+	q encoder,
+) error {
+	if err := send(g.client, method, path, q, g); err != nil {
+		return err
+	}
+	g.setclient(g.client)
+	return nil
+}
+
+// UsersIter returns an iterator over the users in the group.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.groups.users
+func (g *Group) UsersIter(opts ...Option) *UserIterator {
+	return newUserIterator(g.client, g.id("/groups/%d/users"), opts)
+}
+
+// ListUsers returns a slice of the users in the group.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.groups.users
+func (g *Group) ListUsers(opts ...Option) ([]*User, error) {
+	return collectUsers(g.UsersIter(opts...))
+}
+
+// Memberships returns a slice of the group's memberships.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.group_memberships.index
+func (g *Group) Memberships(opts ...Option) (list []*GroupMembership, err error) {
+	err = getList(g.client, func(r io.Reader) error {
+		page := make([]*GroupMembership, 0)
+		if err := json.NewDecoder(r).Decode(&page); err != nil {
+			return err
+		}
+		list = append(list, page...)
+		return nil
+	}, g.id("/groups/%d/memberships"), opts)
+	return list, err
+}
+
+// AddUser will add a user to the group.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.group_memberships.create
+func (g *Group) AddUser(userID int) (*GroupMembership, error) {
+	resp, err := post(g.client, g.id("/groups/%d/memberships"), params{
+		"user_id": {fmt.Sprintf("%d", userID)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	m := &GroupMembership{}
+	return m, json.NewDecoder(resp.Body).Decode(m)
+}
+
+// UpdateMembership will update a user's membership in the group.
+// Options can be "moderator" or "workflow_state" (only "accepted").
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.group_memberships.update
+func (g *Group) UpdateMembership(userID int, opts ...Option) (*GroupMembership, error) {
+	resp, err := put(g.client, fmt.Sprintf("/groups/%d/users/%d", g.ID, userID), optEnc(opts))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	m := &GroupMembership{}
+	return m, json.NewDecoder(resp.Body).Decode(m)
+}
+
+// RemoveUser will remove a user from the group.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.group_memberships.destroy
+func (g *Group) RemoveUser(userID int) error {
+	resp, err := delete(g.client, fmt.Sprintf("/groups/%d/users/%d", g.ID, userID), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Files returns a channel of the group's files.
+func (g *Group) Files(opts ...Option) <-chan *File {
+	return filesChannel(g.FilesIter(opts...), ConcurrentErrorHandler)
+}
+
+// FilesIter returns an iterator over the group's files.
+//
+// https://canvas.instructure.com/doc/api/files.html#method.files.api_index
+func (g *Group) FilesIter(opts ...Option) *FileIterator {
+	return newFileIterator(g.client, g.id("/groups/%d/files"), nil, opts)
+}
+
+// ListFiles returns a slice of the group's files.
+//
+// https://canvas.instructure.com/doc/api/files.html#method.files.api_index
+func (g *Group) ListFiles(opts ...Option) ([]*File, error) {
+	return listFiles(g.client, g.id("/groups/%d/files"), nil, opts)
+}
+
+// File will get one of the group's files given a file id.
+func (g *Group) File(id int, opts ...Option) (*File, error) {
+	f := &File{client: g.client}
+	return f, getjson(g.client, f, optEnc(opts), "/groups/%d/files/%d", g.ID, id)
+}
+
+// Folders returns a channel of the group's folders.
+func (g *Group) Folders(opts ...Option) <-chan *Folder {
+	return foldersChannel(g.FoldersIter(opts...), ConcurrentErrorHandler)
+}
+
+// FoldersIter returns an iterator over the group's folders.
+//
+// https://canvas.instructure.com/doc/api/files.html#method.folders.list_all_folders
+func (g *Group) FoldersIter(opts ...Option) *FolderIterator {
+	return newFolderIterator(g.client, g.id("/groups/%d/folders"), nil, opts)
+}
+
+// ListFolders returns a slice of the group's folders.
+//
+// https://canvas.instructure.com/doc/api/files.html#method.folders.list_all_folders
+func (g *Group) ListFolders(opts ...Option) ([]*Folder, error) {
+	return listFolders(g.client, g.id("/groups/%d/folders"), nil, opts)
+}
+
+// Root will get the root folder for the group's files.
+func (g *Group) Root(opts ...Option) (*Folder, error) {
+	f := &Folder{client: g.client}
+	return f, getjson(g.client, f, optEnc(opts), "/groups/%d/folders/root", g.ID)
+}
+
+// FolderPath will split the path and return a list containing
+// all of the folders in the path.
+func (g *Group) FolderPath(pth string) ([]*Folder, error) {
+	pth = path.Join(g.id("/groups/%d/folders/by_path"), pth)
+	return folderList(g.client, pth)
+}
+
+// CreateFolder will create a new folder in the group's files.
+//
+// https://canvas.instructure.com/doc/api/files.html#method.folders.create
+func (g *Group) CreateFolder(path string, opts ...Option) (*Folder, error) {
+	dir, name := filepath.Split(path)
+	return createFolder(g.client, dir, name, opts, "/groups/%d/folders", g.ID)
+}
+
+// UploadFile will upload a file to the group.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.groups.create_file
+func (g *Group) UploadFile(filename string, r io.Reader, opts ...Option) (*File, error) {
+	return uploadFile(g.client, r, g.id("/groups/%d/files"), newFileUploadParams(filename, opts))
+}
+
+// DiscussionTopics returns a slice of the group's discussion topics.
+func (g *Group) DiscussionTopics(opts ...Option) ([]*DiscussionTopic, error) {
+	return collectDiscussionTopics(g.DiscussionTopicsIter(opts...))
+}
+
+// DiscussionTopicsIter returns an iterator over the group's discussion topics.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics.index
+func (g *Group) DiscussionTopicsIter(opts ...Option) *DiscussionTopicIterator {
+	return newDiscussionTopicIterator(g.client, g.id("/groups/%d/discussion_topics"), opts)
+}
+
+// ContextCode returns the group's context code.
+func (g *Group) ContextCode() string {
+	return fmt.Sprintf("group_%d", g.ID)
+}
+
+func (g *Group) id(s string) string {
+	return fmt.Sprintf(s, g.ID)
+}
+
+func (g *Group) setclient(d doer) {
+	g.client = d
+	for _, u := range g.Users {
+		u.client = d
+	}
+}
+
+func getGroup(d doer, id int, opts []Option) (*Group, error) {
+	g := &Group{}
+	if err := getjson(d, g, optEnc(opts), "/groups/%d", id); err != nil {
+		return nil, err
+	}
+	g.setclient(d)
+	return g, nil
+}
+
+func createGroupCategory(d doer, path string, gc GroupCategory, opts []Option) (*GroupCategory, error) {
+	q, err := query.Values(&gc)
+	if err != nil {
+		return nil, err
+	}
+	params(q).Add(opts)
+	resp, err := post(d, path, q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	cat := &GroupCategory{}
+	if err = json.NewDecoder(resp.Body).Decode(cat); err != nil {
+		return nil, err
+	}
+	cat.setclient(d)
+	return cat, nil
+}
+
+// GroupIterator iterates over a paginated list of groups.
+type GroupIterator struct{ *iterator }
+
+// Value returns the current group.
+func (it *GroupIterator) Value() *Group {
+	g, _ := it.cur.(*Group)
+	return g
+}
+
+func newGroupIterator(d doer, path string, opts []Option) *GroupIterator {
+	return &GroupIterator{newIterator(d, path, opts, func(r io.Reader, emit emitFunc) error {
+		list := make([]*Group, 0, defaultPerPage)
+		if err := json.NewDecoder(r).Decode(&list); err != nil {
+			return err
+		}
+		for _, g := range list {
+			g.setclient(d)
+			if err := emit(g); err != nil {
+				return err
+			}
+		}
+		return nil
+	})}
+}
+
+func collectGroups(it *GroupIterator) ([]*Group, error) {
+	groups := make([]*Group, 0)
+	err := collect(it.iterator, &groups)
+	return groups, err
+}
+
+// groupsChannel sends the groups from an iterator over a
+// channel and passes any errors to the error handler.
+func groupsChannel(it *GroupIterator, handler errorHandlerFunc) <-chan *Group {
+	it.handler = handler
+	ch := make(chan *Group)
+	stream(it.iterator, ch)
+	return ch
+}
+
+// GroupCategoryIterator iterates over a paginated list of group categories.
+type GroupCategoryIterator struct{ *iterator }
+
+// Value returns the current group category.
+func (it *GroupCategoryIterator) Value() *GroupCategory {
+	gc, _ := it.cur.(*GroupCategory)
+	return gc
+}
+
+func newGroupCategoryIterator(d doer, path string, opts []Option) *GroupCategoryIterator {
+	return &GroupCategoryIterator{newIterator(d, path, opts, func(r io.Reader, emit emitFunc) error {
+		list := make([]*GroupCategory, 0, defaultPerPage)
+		if err := json.NewDecoder(r).Decode(&list); err != nil {
+			return err
+		}
+		for _, gc := range list {
+			gc.setclient(d)
+			if err := emit(gc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})}
+}
+
+func collectGroupCategories(it *GroupCategoryIterator) ([]*GroupCategory, error) {
+	list := make([]*GroupCategory, 0)
+	err := collect(it.iterator, &list)
+	return list, err
+}
@@ -0,0 +1,178 @@
+package canvas
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestCourse_Groups(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	c := &Course{ID: 1, client: cli}
+
+	mux.HandleFunc("/api/v1/courses/1/groups", handlePagingatedList(t, 4, "group.json"))
+	mux.HandleFunc("/api/v1/courses/1/group_categories", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`[{"id":2,"name":"Projects","self_signup":"enabled","context_type":"Course","course_id":1}]`))
+		case "POST":
+			q := r.URL.Query()
+			is.Equal(q.Get("name"), "Labs")
+			is.Equal(q.Get("group_limit"), "4")
+			is.Equal(q.Get("create_group_count"), "3")
+			is.Equal(len(q["self_signup"]), 0)
+			w.Write([]byte(`{"id":3,"name":"Labs","group_limit":4,"progress":{"id":9,"workflow_state":"queued"}}`))
+		}
+	})
+
+	groups, err := c.ListGroups()
+	is.NoErr(err)
+	is.Equal(len(groups), 4)
+	is.Equal(groups[0].Name, "Math Group 1")
+	is.Equal(groups[0].Permissions.CreateDiscussionTopic, true)
+	is.True(groups[0].client != nil)
+
+	cats, err := c.GroupCategories()
+	is.NoErr(err)
+	is.Equal(len(cats), 1)
+	is.Equal(cats[0].SelfSignup, "enabled")
+
+	cat, err := c.CreateGroupCategory(GroupCategory{Name: "Labs", GroupLimit: 4}, Opt("create_group_count", 3))
+	is.NoErr(err)
+	is.Equal(cat.ID, 3)
+	is.Equal(cat.Progress.ID, 9)
+	is.True(cat.Progress.client != nil)
+}
+
+func TestGroupCategory(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	gc := &GroupCategory{ID: 2, client: cli}
+
+	mux.HandleFunc("/api/v1/group_categories/2/assign_unassigned_members", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "POST")
+		w.Write([]byte(`{"id":11,"workflow_state":"running","completion":50}`))
+	})
+	mux.HandleFunc("/api/v1/group_categories/2/groups", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			handlePagingatedList(t, 2, "group.json")(w, r)
+		case "POST":
+			is.Equal(r.URL.Query().Get("name"), "Math Group 1")
+			is.Equal(r.URL.Query().Get("join_level"), "invitation_only")
+			writeTestFile(t, "group.json", w)
+		}
+	})
+	mux.HandleFunc("/api/v1/group_categories/2/users", func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(r.URL.Query().Get("unassigned"), "true")
+		handlePagingatedList(t, 2, "user.json")(w, r)
+	})
+
+	p, err := gc.AssignUnassignedMembers()
+	is.NoErr(err)
+	is.Equal(p.ID, 11)
+	is.Equal(p.Completion, 50.0)
+
+	groups, err := gc.ListGroups()
+	is.NoErr(err)
+	is.Equal(len(groups), 2)
+
+	g, err := gc.CreateGroup(Group{Name: "Math Group 1", JoinLevel: "invitation_only"})
+	is.NoErr(err)
+	is.Equal(g.ID, 17)
+
+	users, err := gc.ListUsers(Opt("unassigned", true))
+	is.NoErr(err)
+	is.Equal(len(users), 2)
+}
+
+func TestGroup_Memberships(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	g := &Group{ID: 17, client: cli}
+
+	mux.HandleFunc("/api/v1/groups/17/memberships", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`[{"id":1,"group_id":17,"user_id":2,"workflow_state":"accepted"},{"id":2,"group_id":17,"user_id":3,"workflow_state":"invited"}]`))
+		case "POST":
+			is.Equal(r.URL.Query().Get("user_id"), "3")
+			w.Write([]byte(`{"id":2,"group_id":17,"user_id":3,"workflow_state":"invited","just_created":true}`))
+		}
+	})
+	var method string
+	mux.HandleFunc("/api/v1/groups/17/users/3", func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		if r.Method == "PUT" {
+			is.Equal(r.URL.Query().Get("moderator"), "true")
+			w.Write([]byte(`{"id":2,"group_id":17,"user_id":3,"workflow_state":"accepted","moderator":true}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+
+	list, err := g.Memberships()
+	is.NoErr(err)
+	is.Equal(len(list), 2)
+	is.Equal(list[1].WorkflowState, "invited")
+
+	m, err := g.AddUser(3)
+	is.NoErr(err)
+	is.True(m.JustCreated)
+
+	m, err = g.UpdateMembership(3, Opt("moderator", true))
+	is.NoErr(err)
+	is.True(m.Moderator)
+
+	is.NoErr(g.RemoveUser(3))
+	is.Equal(method, "DELETE")
+}
+
+func TestGroup_Files(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	g := &Group{ID: 17, client: cli}
+
+	mux.HandleFunc("/api/v1/groups/17/files", handlePagingatedList(t, 3, "file.json"))
+	mux.HandleFunc("/api/v1/groups/17/folders", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			handlePagingatedList(t, 2, "folder.json")(w, r)
+		case "POST":
+			is.Equal(r.URL.Query().Get("name"), "notes")
+			is.Equal(r.URL.Query().Get("parent_folder_path"), "/shared/")
+			writeTestFile(t, "folder.json", w)
+		}
+	})
+	mux.HandleFunc("/api/v1/groups/17/discussion_topics", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id":1,"title":"first"},{"id":2,"title":"second"}]`))
+	})
+
+	files, err := g.ListFiles()
+	is.NoErr(err)
+	is.Equal(len(files), 3)
+	n := 0
+	for range g.Files() {
+		n++
+	}
+	is.Equal(n, 3)
+
+	folders, err := g.ListFolders()
+	is.NoErr(err)
+	is.Equal(len(folders), 2)
+
+	_, err = g.CreateFolder("/shared/notes")
+	is.NoErr(err)
+
+	topics, err := g.DiscussionTopics()
+	is.NoErr(err)
+	is.Equal(len(topics), 2)
+	is.Equal(topics[1].Title, "second")
+	is.Equal(g.ContextCode(), "group_17")
+}
@@ -0,0 +1,136 @@
+package canvas
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+)
+
+type emitFunc func(interface{}) error
+
+// iterator is used by all of the typed iterators. It will request pages
+// in the background and hand back the items one at a time. The pager is
+// not started until the first call to Next.
+type iterator struct {
+	pager  *paginated
+	ctx    context.Context
+	cancel context.CancelFunc
+	items  chan interface{}
+	errs   <-chan error
+
+	cur  interface{}
+	err  error
+	done bool
+
+	// If handler is not nil then errors are given to the handler and
+	// iteration only stops when the handler returns an error.
+	handler errorHandlerFunc
+}
+
+// newIterator creates an iterator for a paginated list. The decode
+// function should decode one page and emit each item in the page.
+func newIterator(
+	d doer,
+	path string,
+	opts []Option,
+	decode func(io.Reader, emitFunc) error,
+) *iterator {
+	ctx, cancel := context.WithCancel(contextOf(d))
+	it := &iterator{
+		ctx:    ctx,
+		cancel: cancel,
+		items:  make(chan interface{}),
+	}
+	// The pager gets its own context so that closing the
+	// iterator will stop any requests that are still running.
+	it.pager = newPaginatedList(
+		withContext(d, ctx), path,
+		func(r io.Reader) error { return decode(r, it.emit) },
+		opts,
+	)
+	return it
+}
+
+// Next advances the iterator. It returns false once all the items have
+// been read, the iterator has been closed, or if there was an error.
+func (it *iterator) Next() bool {
+	if it.done {
+		return false
+	}
+	if it.errs == nil {
+		it.errs = it.pager.start()
+	}
+	for {
+		select {
+		case v := <-it.items:
+			it.cur = v
+			return true
+		case err, ok := <-it.errs:
+			if !ok {
+				it.Close()
+				return false
+			}
+			if it.handler != nil && !isContextErr(err) {
+				if err = it.handler(err); err == nil {
+					continue
+				}
+			}
+			it.err = err
+			it.Close()
+			return false
+		}
+	}
+}
+
+// Err returns the error that stopped the iteration.
+func (it *iterator) Err() error {
+	return it.err
+}
+
+// Close stops the iteration and any requests that are still running.
+// Close should be called if the iterator is not read until Next
+// returns false.
+func (it *iterator) Close() {
+	it.done = true
+	it.cur = nil
+	it.cancel()
+}
+
+func (it *iterator) emit(v interface{}) error {
+	select {
+	case it.items <- v:
+		return nil
+	case <-it.ctx.Done():
+		return it.ctx.Err()
+	}
+}
+
+func isContextErr(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// stream sends each item from the iterator over ch in a new goroutine.
+// ch must be a channel of the iterator's item type and is closed once
+// the iterator is done.
+func stream(it *iterator, ch interface{}) {
+	c := reflect.ValueOf(ch)
+	go func() {
+		defer c.Close()
+		defer it.Close()
+		for it.Next() {
+			c.Send(reflect.ValueOf(it.cur))
+		}
+	}()
+}
+
+// collect appends each item from the iterator to list, which must
+// be a pointer to a slice of the iterator's item type.
+func collect(it *iterator, list interface{}) error {
+	defer it.Close()
+	v := reflect.ValueOf(list).Elem()
+	for it.Next() {
+		v.Set(reflect.Append(v, reflect.ValueOf(it.cur)))
+	}
+	return it.Err()
+}
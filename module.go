@@ -0,0 +1,440 @@
+package canvas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/harrybrwn/go-querystring/query"
+)
+
+// Module is a course module.
+//
+// https://canvas.instructure.com/doc/api/modules.html
+type Module struct {
+	ID            int    `json:"id" url:"-"`
+	WorkflowState string `json:"workflow_state" url:"-"`
+	Position      int    `json:"position" url:"position,omitempty"`
+	Name          string `json:"name" url:"name,omitempty"`
+	// UnlockAt is when the module will unlock.
+	UnlockAt time.Time `json:"unlock_at" url:"unlock_at,omitempty"`
+	// RequireSequentialProgress is true if the module's
+	// items must be completed in order.
+	RequireSequentialProgress bool `json:"require_sequential_progress" url:"require_sequential_progress,omitempty"`
+	// PrerequisiteModuleIDs are the modules that must be
+	// completed before this module is unlocked.
+	PrerequisiteModuleIDs []int  `json:"prerequisite_module_ids" url:"prerequisite_module_ids,brackets,omitempty"`
+	PublishFinalGrade     bool   `json:"publish_final_grade" url:"publish_final_grade,omitempty"`
+	Published             bool   `json:"published" url:"published,omitempty"`
+	ItemsCount            int    `json:"items_count" url:"-"`
+	ItemsURL              string `json:"items_url" url:"-"`
+	// Items is only set if the module was requested with
+	// the "items" include option.
+	Items []*ModuleItem `json:"items" url:"-"`
+	// State is the state of the module for the current user and
+	// can be "locked", "unlocked", "started", or "completed".
+	State       string    `json:"state" url:"-"`
+	CompletedAt time.Time `json:"completed_at" url:"-"`
+
+	courseID int
+	client   doer
+}
+
+type moduleOptions struct {
+	Module `url:"module"`
+}
+
+// ModuleItemType is the type of content that a module item links to.
+type ModuleItemType string
+
+// Module item types
+const (
+	ModuleItemFile         ModuleItemType = "File"
+	ModuleItemPage         ModuleItemType = "Page"
+	ModuleItemDiscussion   ModuleItemType = "Discussion"
+	ModuleItemAssignment   ModuleItemType = "Assignment"
+	ModuleItemQuiz         ModuleItemType = "Quiz"
+	ModuleItemSubHeader    ModuleItemType = "SubHeader"
+	ModuleItemExternalURL  ModuleItemType = "ExternalUrl"
+	ModuleItemExternalTool ModuleItemType = "ExternalTool"
+)
+
+// RequirementType is the type of completion requirement for a module item.
+type RequirementType string
+
+// Completion requirement types
+const (
+	MustView       RequirementType = "must_view"
+	MustContribute RequirementType = "must_contribute"
+	MustSubmit     RequirementType = "must_submit"
+	MustMarkDone   RequirementType = "must_mark_done"
+	MinScore       RequirementType = "min_score"
+)
+
+// CompletionRequirement is what a student must do to complete a module item.
+type CompletionRequirement struct {
+	Type RequirementType `json:"type" url:"type,omitempty"`
+	// MinScore is only used with the MinScore requirement type.
+	MinScore float64 `json:"min_score" url:"min_score,omitempty"`
+	// Completed is true if the current user has met the requirement.
+	Completed bool `json:"completed" url:"-"`
+}
+
+// ModuleItem is an item in a module.
+//
+// https://canvas.instructure.com/doc/api/modules.html#ModuleItem
+type ModuleItem struct {
+	ID       int            `json:"id" url:"-"`
+	ModuleID int            `json:"module_id" url:"-"`
+	Position int            `json:"position" url:"position,omitempty"`
+	Title    string         `json:"title" url:"title,omitempty"`
+	Indent   int            `json:"indent" url:"indent,omitempty"`
+	Type     ModuleItemType `json:"type" url:"type,omitempty"`
+	// ContentID is the id of the file, discussion, assignment,
+	// quiz, or external tool that the item links to.
+	ContentID int    `json:"content_id" url:"content_id,omitempty"`
+	HTMLURL   string `json:"html_url" url:"-"`
+	URL       string `json:"url" url:"-"`
+	// PageURL is the url of the wiki page for ModuleItemPage items.
+	PageURL string `json:"page_url" url:"page_url,omitempty"`
+	// ExternalURL is used for ModuleItemExternalURL
+	// and ModuleItemExternalTool items.
+	ExternalURL           string                 `json:"external_url" url:"external_url,omitempty"`
+	NewTab                bool                   `json:"new_tab" url:"new_tab,omitempty"`
+	CompletionRequirement *CompletionRequirement `json:"completion_requirement" url:"completion_requirement,omitempty"`
+	Published             bool                   `json:"published" url:"published,omitempty"`
+	ContentDetails        struct {
+		PointsPossible  float64   `json:"points_possible"`
+		DueAt           time.Time `json:"due_at"`
+		UnlockAt        time.Time `json:"unlock_at"`
+		LockAt          time.Time `json:"lock_at"`
+		LockedForUser   bool      `json:"locked_for_user"`
+		LockExplanation string    `json:"lock_explanation"`
+	} `json:"content_details" url:"-"`
+
+	courseID int
+	client   doer
+}
+
+type moduleItemOptions struct {
+	ModuleItem `url:"module_item"`
+}
+
+// WithContext returns a copy of the module that uses ctx.
+func (m *Module) WithContext(ctx context.Context) *Module {
+	cp := *m
+	cp.client = withContext(m.client, ctx)
+	return &cp
+}
+
+// WithContext returns a copy of the module item that uses ctx.
+func (mi *ModuleItem) WithContext(ctx context.Context) *ModuleItem {
+	cp := *mi
+	cp.client = withContext(mi.client, ctx)
+	return &cp
+}
+
+// Modules returns a channel of the course's modules.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_modules_api.index
+func (c *Course) Modules(opts ...Option) <-chan *Module {
+	it := c.ModulesIter(opts...)
+	it.handler = c.errorHandler
+	ch := make(chan *Module)
+	stream(it.iterator, ch)
+	return ch
+}
+
+// ModulesIter returns an iterator over the course's modules.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_modules_api.index
+func (c *Course) ModulesIter(opts ...Option) *ModuleIterator {
+	return &ModuleIterator{newIterator(c.client, c.id("/courses/%d/modules"), opts, func(r io.Reader, emit emitFunc) error {
+		list := make([]*Module, 0, defaultPerPage)
+		if err := json.NewDecoder(r).Decode(&list); err != nil {
+			return err
+		}
+		for _, m := range list {
+			m.setclient(c.client, c.ID)
+			if err := emit(m); err != nil {
+				return err
+			}
+		}
+		return nil
+	})}
+}
+
+// ListModules returns a slice of the course's modules.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_modules_api.index
+func (c *Course) ListModules(opts ...Option) ([]*Module, error) {
+	it := c.ModulesIter(opts...)
+	modules := make([]*Module, 0)
+	err := collect(it.iterator, &modules)
+	return modules, err
+}
+
+// Module will get one of the course's modules given its id.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_modules_api.show
+func (c *Course) Module(id int, opts ...Option) (*Module, error) {
+	m := &Module{}
+	if err := getjson(c.client, m, optEnc(opts), "/courses/%d/modules/%d", c.ID, id); err != nil {
+		return nil, err
+	}
+	m.setclient(c.client, c.ID)
+	return m, nil
+}
+
+// CreateModule will create a new module in the course.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_modules_api.create
+func (c *Course) CreateModule(m Module, opts ...Option) (*Module, error) {
+	q, err := query.Values(&moduleOptions{m})
+	if err != nil {
+		return nil, err
+	}
+	params(q).Add(opts)
+	mod := &Module{}
+	if err = send(c.client, post, c.id("/courses/%d/modules"), q, mod); err != nil {
+		return nil, err
+	}
+	mod.setclient(c.client, c.ID)
+	return mod, nil
+}
+
+// Update will send the module's fields to canvas and replace them with the
+// updated module. Empty fields are left out so Update cannot unpublish the
+// module, use Unpublish instead. Options can be used to send other values
+// that are left out when they are empty.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_modules_api.update
+func (m *Module) Update(opts ...Option) error {
+	q, err := query.Values(&moduleOptions{*m})
+	if err != nil {
+		return err
+	}
+	params(q).Add(opts)
+	return m.send(put, m.path(), q)
+}
+
+// Delete will delete the module.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_modules_api.destroy
+func (m *Module) Delete() error {
+	return m.send(delete, m.path(), nil)
+}
+
+// Publish will publish the module.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_modules_api.update
+func (m *Module) Publish() error {
+	return m.send(put, m.path(), params{"module[published]": {"true"}})
+}
+
+// Unpublish will unpublish the module.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_modules_api.update
+func (m *Module) Unpublish() error {
+	return m.send(put, m.path(), params{"module[published]": {"false"}})
+}
+
+// Relock will reset the module's progress for all students so that
+// any new requirements or prerequisites are applied to them.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_modules_api.relock
+func (m *Module) Relock() error {
+	return m.send(put, m.path()+"/relock", nil)
+}
+
+func (m *Module) send(
+	method func(doer, string, encoder) (*http.Response, error),
+	path string,
+	q encoder,
+) error {
+	if err := send(m.client, method, path, q, m); err != nil {
+		return err
+	}
+	m.setclient(m.client, m.courseID)
+	return nil
+}
+
+// ItemsChan returns a channel of the module's items.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_module_items_api.index
+func (m *Module) ItemsChan(opts ...Option) <-chan *ModuleItem {
+	it := m.ItemsIter(opts...)
+	it.handler = ConcurrentErrorHandler
+	ch := make(chan *ModuleItem)
+	stream(it.iterator, ch)
+	return ch
+}
+
+// ItemsIter returns an iterator over the module's items.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_module_items_api.index
+func (m *Module) ItemsIter(opts ...Option) *ModuleItemIterator {
+	return &ModuleItemIterator{newIterator(m.client, m.path()+"/items", opts, func(r io.Reader, emit emitFunc) error {
+		list := make([]*ModuleItem, 0, defaultPerPage)
+		if err := json.NewDecoder(r).Decode(&list); err != nil {
+			return err
+		}
+		for _, item := range list {
+			item.courseID = m.courseID
+			item.client = m.client
+			if err := emit(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})}
+}
+
+// ListItems returns a slice of the module's items.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_module_items_api.index
+func (m *Module) ListItems(opts ...Option) ([]*ModuleItem, error) {
+	it := m.ItemsIter(opts...)
+	items := make([]*ModuleItem, 0)
+	err := collect(it.iterator, &items)
+	return items, err
+}
+
+// Item will get one of the module's items given its id.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_module_items_api.show
+func (m *Module) Item(id int, opts ...Option) (*ModuleItem, error) {
+	item := &ModuleItem{}
+	if err := getjson(m.client, item, optEnc(opts), "%s/items/%d", m.path(), id); err != nil {
+		return nil, err
+	}
+	item.courseID = m.courseID
+	item.client = m.client
+	return item, nil
+}
+
+// CreateItem will add a new item to the module. The item's Type is
+// required and, except for ModuleItemSubHeader, ModuleItemPage and
+// ModuleItemExternalURL items, so is its ContentID.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_module_items_api.create
+func (m *Module) CreateItem(item ModuleItem, opts ...Option) (*ModuleItem, error) {
+	q, err := query.Values(&moduleItemOptions{item})
+	if err != nil {
+		return nil, err
+	}
+	params(q).Add(opts)
+	mi := &ModuleItem{}
+	if err = send(m.client, post, m.path()+"/items", q, mi); err != nil {
+		return nil, err
+	}
+	mi.courseID = m.courseID
+	mi.client = m.client
+	return mi, nil
+}
+
+func (m *Module) path() string {
+	return fmt.Sprintf("/courses/%d/modules/%d", m.courseID, m.ID)
+}
+
+func (m *Module) setclient(d doer, courseID int) {
+	m.client = d
+	m.courseID = courseID
+	for _, item := range m.Items {
+		item.client = d
+		item.courseID = courseID
+	}
+}
+
+// Update will send the item's fields to canvas and replace them with the
+// updated item. Use Unpublish to unpublish the item.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_module_items_api.update
+func (mi *ModuleItem) Update(opts ...Option) error {
+	// the item type and content cannot be changed after it is created
+	item := *mi
+	item.Type, item.ContentID, item.PageURL = "", 0, ""
+	q, err := query.Values(&moduleItemOptions{item})
+	if err != nil {
+		return err
+	}
+	params(q).Add(opts)
+	return send(mi.client, put, mi.path(), q, mi)
+}
+
+// Delete will remove the item from its module.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_module_items_api.destroy
+func (mi *ModuleItem) Delete() error {
+	return send(mi.client, delete, mi.path(), nil, mi)
+}
+
+// Publish will publish the item.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_module_items_api.update
+func (mi *ModuleItem) Publish() error {
+	return send(mi.client, put, mi.path(), params{"module_item[published]": {"true"}}, mi)
+}
+
+// Unpublish will unpublish the item.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_module_items_api.update
+func (mi *ModuleItem) Unpublish() error {
+	return send(mi.client, put, mi.path(), params{"module_item[published]": {"false"}}, mi)
+}
+
+// MarkRead will mark the item as read for the current user.
+// This is used for items with the MustView requirement.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_module_items_api.mark_item_read
+func (mi *ModuleItem) MarkRead() error {
+	return mi.mark(post, "/mark_read")
+}
+
+// MarkDone will mark the item as done for the current user.
+// This is used for items with the MustMarkDone requirement.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_module_items_api.mark_as_done
+func (mi *ModuleItem) MarkDone() error {
+	return mi.mark(put, "/done")
+}
+
+// MarkNotDone will undo MarkDone.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_module_items_api.mark_as_done
+func (mi *ModuleItem) MarkNotDone() error {
+	return mi.mark(delete, "/done")
+}
+
+func (mi *ModuleItem) mark(method func(doer, string, encoder) (*http.Response, error), action string) error {
+	resp, err := method(mi.client, mi.path()+action, nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (mi *ModuleItem) path() string {
+	return fmt.Sprintf("/courses/%d/modules/%d/items/%d", mi.courseID, mi.ModuleID, mi.ID)
+}
+
+// ModuleIterator iterates over a paginated list of modules.
+type ModuleIterator struct{ *iterator }
+
+// Value returns the current module.
+func (it *ModuleIterator) Value() *Module {
+	m, _ := it.cur.(*Module)
+	return m
+}
+
+// ModuleItemIterator iterates over a paginated list of module items.
+type ModuleItemIterator struct{ *iterator }
+
+// Value returns the current module item.
+func (it *ModuleItemIterator) Value() *ModuleItem {
+	mi, _ := it.cur.(*ModuleItem)
+	return mi
+}
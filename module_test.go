@@ -0,0 +1,165 @@
+package canvas
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestCourse_Modules(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	c := &Course{ID: 1, client: cli}
+
+	mux.HandleFunc("/api/v1/courses/1/modules", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			handlePagingatedList(t, 2, "module.json")(w, r)
+		case "POST":
+			q := r.URL.Query()
+			is.Equal(q.Get("module[name]"), "Week 1")
+			is.Equal(q["module[prerequisite_module_ids][]"], []string{"121", "122"})
+			is.Equal(q.Get("module[require_sequential_progress]"), "true")
+			is.Equal(len(q["module[position]"]), 0)
+			writeTestFile(t, "module.json", w)
+		}
+	})
+	mux.HandleFunc("/api/v1/courses/1/modules/123", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "GET")
+		is.Equal(r.URL.Query()["include[]"], []string{"items"})
+		writeTestFile(t, "module.json", w)
+	})
+
+	modules, err := c.ListModules()
+	is.NoErr(err)
+	is.Equal(len(modules), 2)
+	is.Equal(modules[0].PrerequisiteModuleIDs, []int{121, 122})
+	is.Equal(modules[0].courseID, 1)
+
+	m, err := c.Module(123, IncludeOpt("items"))
+	is.NoErr(err)
+	is.Equal(len(m.Items), 1)
+	item := m.Items[0]
+	is.Equal(item.Type, ModuleItemAssignment)
+	is.Equal(item.CompletionRequirement.Type, MinScore)
+	is.Equal(item.CompletionRequirement.MinScore, 10.0)
+	is.True(item.CompletionRequirement.Completed)
+	is.Equal(item.ContentDetails.PointsPossible, 20.0)
+	is.Equal(item.courseID, 1)
+	is.True(item.client != nil)
+
+	m, err = c.CreateModule(Module{
+		Name:                      "Week 1",
+		RequireSequentialProgress: true,
+		PrerequisiteModuleIDs:     []int{121, 122},
+	})
+	is.NoErr(err)
+	is.Equal(m.ID, 123)
+}
+
+func TestModule(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	m := &Module{ID: 123, Name: "renamed", courseID: 1, client: cli}
+
+	var method, path string
+	var query map[string][]string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		method, path, query = r.Method, r.URL.Path, r.URL.Query()
+		writeTestFile(t, "module.json", w)
+	}
+	mux.HandleFunc("/api/v1/courses/1/modules/123", handler)
+	mux.HandleFunc("/api/v1/courses/1/modules/123/relock", handler)
+
+	is.NoErr(m.Update(Opt("module[published]", false)))
+	is.Equal(method, "PUT")
+	is.Equal(query["module[name]"], []string{"renamed"})
+	is.Equal(query["module[published]"], []string{"false"})
+	is.Equal(m.Name, "Imaginary Numbers and You")
+
+	is.NoErr(m.Unpublish())
+	is.Equal(method, "PUT")
+	is.Equal(query["module[published]"], []string{"false"})
+	is.Equal(len(query["module[name]"]), 0)
+	is.NoErr(m.Publish())
+	is.Equal(query["module[published]"], []string{"true"})
+
+	is.NoErr(m.Relock())
+	is.Equal(method, "PUT")
+	is.Equal(path, "/api/v1/courses/1/modules/123/relock")
+
+	is.NoErr(m.Delete())
+	is.Equal(method, "DELETE")
+}
+
+func TestModule_Items(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	m := &Module{ID: 123, courseID: 1, client: cli}
+
+	itemJSON := `{"id":768,"module_id":123,"title":"Week 1 notes","type":"Page","page_url":"week-1","completion_requirement":{"type":"must_view"}}`
+	mux.HandleFunc("/api/v1/courses/1/modules/123/items", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Write([]byte("[" + itemJSON + "," + itemJSON + "]"))
+		case "POST":
+			q := r.URL.Query()
+			is.Equal(q.Get("module_item[type]"), "Page")
+			is.Equal(q.Get("module_item[page_url]"), "week-1")
+			is.Equal(q.Get("module_item[completion_requirement][type]"), "must_view")
+			is.Equal(len(q["module_item[completion_requirement][min_score]"]), 0)
+			w.Write([]byte(itemJSON))
+		}
+	})
+	var method, path string
+	var query map[string][]string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		method, path, query = r.Method, r.URL.Path, r.URL.Query()
+		w.Write([]byte(itemJSON))
+	}
+	mux.HandleFunc("/api/v1/courses/1/modules/123/items/768", handler)
+	mux.HandleFunc("/api/v1/courses/1/modules/123/items/768/mark_read", handler)
+	mux.HandleFunc("/api/v1/courses/1/modules/123/items/768/done", handler)
+
+	items, err := m.ListItems()
+	is.NoErr(err)
+	is.Equal(len(items), 2)
+	n := 0
+	for range m.ItemsChan() {
+		n++
+	}
+	is.Equal(n, 2)
+
+	item, err := m.CreateItem(ModuleItem{
+		Type:                  ModuleItemPage,
+		PageURL:               "week-1",
+		CompletionRequirement: &CompletionRequirement{Type: MustView},
+	})
+	is.NoErr(err)
+	is.Equal(item.ID, 768)
+	is.Equal(item.courseID, 1)
+
+	item.Indent = 1
+	is.NoErr(item.Update())
+	is.Equal(method, "PUT")
+	is.Equal(query["module_item[indent]"], []string{"1"})
+	is.Equal(len(query["module_item[type]"]), 0)
+	is.NoErr(item.Unpublish())
+	is.Equal(query["module_item[published]"], []string{"false"})
+	is.Equal(len(query["module_item[indent]"]), 0)
+
+	is.NoErr(item.MarkRead())
+	is.Equal(method, "POST")
+	is.Equal(path, "/api/v1/courses/1/modules/123/items/768/mark_read")
+	is.NoErr(item.MarkDone())
+	is.Equal(method, "PUT")
+	is.Equal(path, "/api/v1/courses/1/modules/123/items/768/done")
+	is.NoErr(item.MarkNotDone())
+	is.Equal(method, "DELETE")
+	is.NoErr(item.Delete())
+	is.Equal(path, "/api/v1/courses/1/modules/123/items/768")
+}
@@ -0,0 +1,328 @@
+package canvas
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokens are refreshed this long before they actually expire
+const tokenExpiryDelta = 10 * time.Second
+
+// OAuth2 is the configuration for a canvas developer key used to
+// get access tokens on behalf of other users.
+//
+// https://canvas.instructure.com/doc/api/file.oauth.html
+type OAuth2 struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	// Scopes is a list of scopes the tokens will be allowed to use. All
+	// scopes are allowed if the developer key does not enforce scopes.
+	Scopes []string
+	// BaseURL is the url of the canvas instance (ex. "https://canvas.school.edu").
+	// If empty, "https://canvas.instructure.com" is used.
+	BaseURL string
+	// HTTPClient is used to send requests to the oauth2 endpoints.
+	// If nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+}
+
+// Token is an OAuth2 access token.
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	ExpiresIn    int       `json:"expires_in,omitempty"`
+	Expiry       time.Time `json:"expiry,omitempty"`
+	User         struct {
+		ID              int    `json:"id"`
+		Name            string `json:"name"`
+		GlobalID        string `json:"global_id"`
+		EffectiveLocale string `json:"effective_locale"`
+	} `json:"user"`
+}
+
+// Valid returns true if the token has an access
+// token that has not expired.
+func (t *Token) Valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || time.Now().Add(tokenExpiryDelta).Before(t.Expiry)
+}
+
+// TokenSource is anything that can supply access tokens.
+type TokenSource interface {
+	Token() (*Token, error)
+}
+
+// TokenStore is used to persist tokens so they can be used later.
+type TokenStore interface {
+	// Load should return the last token saved.
+	Load() (*Token, error)
+	// Save is called every time a token is refreshed.
+	Save(*Token) error
+}
+
+// StaticTokenSource returns a TokenSource that
+// always returns the same access token.
+func StaticTokenSource(token string) TokenSource {
+	return staticToken{&Token{AccessToken: token, TokenType: "Bearer"}}
+}
+
+type staticToken struct{ tok *Token }
+
+func (st staticToken) Token() (*Token, error) { return st.tok, nil }
+
+// OAuth2Error is an error response from one of the oauth2 endpoints.
+type OAuth2Error struct {
+	Err         string `json:"error"`
+	Description string `json:"error_description"`
+	Status      string `json:"-"`
+}
+
+func (e *OAuth2Error) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("%s: %s", e.Err, e.Description)
+	}
+	if e.Err != "" {
+		return e.Err
+	}
+	return e.Status
+}
+
+// AuthCodeURL returns the url that users should be sent to in order to
+// authorize the application. The state will be sent back to the redirect
+// url along with the authorization code. Options can be used to send
+// any of the optional parameters (ex. purpose, force_login, unique_id, prompt).
+//
+// https://canvas.instructure.com/doc/api/file.oauth_endpoints.html#get-login-oauth2-auth
+func (o *OAuth2) AuthCodeURL(state string, opts ...Option) string {
+	q := params{
+		"client_id":     {o.ClientID},
+		"response_type": {"code"},
+		"redirect_uri":  {o.RedirectURL},
+	}
+	if state != "" {
+		q.Set("state", state)
+	}
+	if len(o.Scopes) > 0 {
+		q.Set("scope", strings.Join(o.Scopes, " "))
+	}
+	q.Add(opts)
+	return fmt.Sprintf("%s/login/oauth2/auth?%s", o.baseURL(), q.Encode())
+}
+
+// Exchange will trade an authorization code for an access token.
+//
+// https://canvas.instructure.com/doc/api/file.oauth_endpoints.html#post-login-oauth2-token
+func (o *OAuth2) Exchange(ctx context.Context, code string) (*Token, error) {
+	return o.token(ctx, url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {o.RedirectURL},
+	})
+}
+
+// Refresh will use a refresh token to get a new access token.
+//
+// https://canvas.instructure.com/doc/api/file.oauth_endpoints.html#post-login-oauth2-token
+func (o *OAuth2) Refresh(ctx context.Context, refreshToken string) (*Token, error) {
+	tok, err := o.token(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	})
+	if err != nil {
+		return nil, err
+	}
+	// canvas does not send a new refresh token
+	if tok.RefreshToken == "" {
+		tok.RefreshToken = refreshToken
+	}
+	return tok, nil
+}
+
+// Revoke will delete an access token so that it can no longer be used.
+//
+// https://canvas.instructure.com/doc/api/file.oauth_endpoints.html#delete-login-oauth2-token
+func (o *OAuth2) Revoke(ctx context.Context, tok *Token) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", o.baseURL()+"/login/oauth2/token", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+tok.AccessToken)
+	resp, err := o.do(req)
+	if err != nil {
+		return err
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	return resp.Body.Close()
+}
+
+// TokenSource returns a TokenSource that will refresh the token
+// when it expires. If tok is nil then the token will be loaded
+// from the store. If the store is not nil then every new token will be
+// saved to the store.
+func (o *OAuth2) TokenSource(tok *Token, store TokenStore) (TokenSource, error) {
+	if tok == nil {
+		if store == nil {
+			return nil, errors.New("no token and no token store")
+		}
+		var err error
+		if tok, err = store.Load(); err != nil {
+			return nil, err
+		}
+	}
+	return &oauthSource{conf: o, tok: tok, store: store}, nil
+}
+
+// NewClient returns a Canvas object that sends requests on behalf of the
+// owner of the token, refreshing the token whenever it expires.
+func (o *OAuth2) NewClient(tok *Token, store TokenStore, opts ...ClientOption) (*Canvas, error) {
+	src, err := o.TokenSource(tok, store)
+	if err != nil {
+		return nil, err
+	}
+	if o.BaseURL != "" {
+		opts = append([]ClientOption{WithBaseURL(o.BaseURL)}, opts...)
+	}
+	return NewClient("", append(opts, WithTokenSource(src))...)
+}
+
+func (o *OAuth2) token(ctx context.Context, form url.Values) (*Token, error) {
+	form.Set("client_id", o.ClientID)
+	form.Set("client_secret", o.ClientSecret)
+	req, err := http.NewRequestWithContext(
+		ctx, "POST", o.baseURL()+"/login/oauth2/token",
+		strings.NewReader(form.Encode()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := o.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	tok := &Token{}
+	if err = json.NewDecoder(resp.Body).Decode(tok); err != nil {
+		return nil, err
+	}
+	if tok.ExpiresIn > 0 {
+		tok.Expiry = time.Now().Add(time.Duration(tok.ExpiresIn) * time.Second)
+	}
+	return tok, nil
+}
+
+func (o *OAuth2) do(req *http.Request) (*http.Response, error) {
+	c := o.HTTPClient
+	if c == nil {
+		c = http.DefaultClient
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	e := &OAuth2Error{Status: resp.Status}
+	json.NewDecoder(resp.Body).Decode(e)
+	return nil, e
+}
+
+func (o *OAuth2) baseURL() string {
+	if o.BaseURL == "" {
+		return defaultBaseURL
+	}
+	return strings.TrimRight(o.BaseURL, "/")
+}
+
+// oauthSource is a token source that refreshes its token when it
+// expires and saves the new token to a token store.
+type oauthSource struct {
+	conf  *OAuth2
+	store TokenStore
+
+	mu  sync.Mutex
+	tok *Token
+}
+
+func (s *oauthSource) Token() (*Token, error) {
+	return s.token(context.Background())
+}
+
+// token returns the current token and uses ctx
+// for the refresh request if it has expired.
+func (s *oauthSource) token(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	tok := s.tok
+	s.mu.Unlock()
+	if tok.Valid() {
+		return tok, nil
+	}
+	return s.refresh(ctx, tok)
+}
+
+// refresh will get a new token unless the expired
+// token has already been replaced.
+func (s *oauthSource) refresh(ctx context.Context, expired *Token) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tok != expired && s.tok.Valid() {
+		return s.tok, nil
+	}
+	if s.tok.RefreshToken == "" {
+		return nil, errors.New("token expired and has no refresh token")
+	}
+	tok, err := s.conf.Refresh(ctx, s.tok.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	s.tok = tok
+	if s.store != nil {
+		if err = s.store.Save(tok); err != nil {
+			return nil, err
+		}
+	}
+	return tok, nil
+}
+
+// refresher is a TokenSource that can be told
+// that one of its tokens has been rejected.
+type refresher interface {
+	refresh(context.Context, *Token) (*Token, error)
+}
+
+// contextTokenSource is a TokenSource that can
+// send requests with a context to get a token.
+type contextTokenSource interface {
+	token(context.Context) (*Token, error)
+}
+
+// sourceToken gets a token from src using
+// ctx if src sends any requests.
+func sourceToken(ctx context.Context, src TokenSource) (*Token, error) {
+	if s, ok := src.(contextTokenSource); ok {
+		return s.token(ctx)
+	}
+	return src.Token()
+}
+
+var (
+	_ TokenSource        = (*oauthSource)(nil)
+	_ refresher          = (*oauthSource)(nil)
+	_ contextTokenSource = (*oauthSource)(nil)
+	_ TokenSource        = staticToken{}
+)
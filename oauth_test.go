@@ -0,0 +1,180 @@
+package canvas
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+type memTokenStore struct {
+	tok   *Token
+	saves int
+}
+
+func (m *memTokenStore) Load() (*Token, error) { return m.tok, nil }
+func (m *memTokenStore) Save(t *Token) error   { m.tok = t; m.saves++; return nil }
+
+func testOAuth2(t *testing.T) (*OAuth2, *http.ServeMux, *httptest.Server) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	return &OAuth2{
+		ClientID:     "10",
+		ClientSecret: "secret",
+		RedirectURL:  "https://example.com/callback",
+		BaseURL:      server.URL,
+	}, mux, server
+}
+
+func TestOAuth2_AuthCodeURL(t *testing.T) {
+	is := is.New(t)
+	conf := &OAuth2{
+		ClientID:    "10",
+		RedirectURL: "https://example.com/callback",
+		Scopes:      []string{"url:GET|/api/v1/courses", "url:GET|/api/v1/users/:id"},
+	}
+	u, err := url.Parse(conf.AuthCodeURL("xyz", Opt("force_login", 1)))
+	is.NoErr(err)
+	is.Equal(u.Host, "canvas.instructure.com")
+	is.Equal(u.Path, "/login/oauth2/auth")
+	q := u.Query()
+	is.Equal(q.Get("client_id"), "10")
+	is.Equal(q.Get("response_type"), "code")
+	is.Equal(q.Get("redirect_uri"), "https://example.com/callback")
+	is.Equal(q.Get("state"), "xyz")
+	is.Equal(q.Get("scope"), "url:GET|/api/v1/courses url:GET|/api/v1/users/:id")
+	is.Equal(q.Get("force_login"), "1")
+}
+
+func TestOAuth2_Exchange(t *testing.T) {
+	is := is.New(t)
+	conf, mux, server := testOAuth2(t)
+	defer server.Close()
+	mux.HandleFunc("/login/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "POST")
+		is.NoErr(r.ParseForm())
+		if r.PostForm.Get("client_secret") != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid_client","error_description":"unknown client"}`))
+			return
+		}
+		is.Equal(r.PostForm.Get("grant_type"), "authorization_code")
+		is.Equal(r.PostForm.Get("code"), "abc")
+		is.Equal(r.PostForm.Get("client_id"), "10")
+		is.Equal(r.PostForm.Get("redirect_uri"), "https://example.com/callback")
+		w.Write([]byte(`{"access_token":"one","token_type":"Bearer","refresh_token":"r","expires_in":3600,"user":{"id":42,"name":"test"}}`))
+	})
+	tok, err := conf.Exchange(context.Background(), "abc")
+	is.NoErr(err)
+	is.Equal(tok.AccessToken, "one")
+	is.Equal(tok.RefreshToken, "r")
+	is.Equal(tok.User.ID, 42)
+	is.True(tok.Valid())
+	is.True(tok.Expiry.After(time.Now().Add(59 * time.Minute)))
+
+	conf.ClientSecret = "wrong"
+	_, err = conf.Exchange(context.Background(), "abc")
+	e, ok := err.(*OAuth2Error)
+	is.True(ok)
+	is.Equal(e.Err, "invalid_client")
+	is.Equal(e.Error(), "invalid_client: unknown client")
+}
+
+func TestOAuth2_Refresh(t *testing.T) {
+	is := is.New(t)
+	conf, mux, server := testOAuth2(t)
+	defer server.Close()
+
+	var refreshes int
+	mux.HandleFunc("/login/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		is.NoErr(r.ParseForm())
+		is.Equal(r.PostForm.Get("grant_type"), "refresh_token")
+		is.Equal(r.PostForm.Get("refresh_token"), "r")
+		refreshes++
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "new", "token_type": "Bearer", "expires_in": 3600,
+		})
+	})
+	var revoked bool
+	mux.HandleFunc("/api/v1/users/self", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "Bearer new":
+			writeTestFile(t, "user.json", w)
+		case "Bearer revoked":
+			revoked = true
+			fallthrough
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"errors":[{"message":"Invalid access token."}]}`))
+		}
+	})
+
+	expired := &Token{AccessToken: "old", RefreshToken: "r", Expiry: time.Now().Add(-time.Minute)}
+	store := &memTokenStore{tok: expired}
+	canv, err := conf.NewClient(nil, store)
+	is.NoErr(err)
+	u, err := canv.CurrentUser()
+	is.NoErr(err)
+	is.Equal(u.ID, 2)
+	is.Equal(refreshes, 1)
+	is.Equal(store.saves, 1)
+	is.Equal(store.tok.AccessToken, "new")
+	is.Equal(store.tok.RefreshToken, "r") // refresh token should be kept
+
+	// refresh tokens that are rejected before they expire
+	store = &memTokenStore{}
+	canv, err = conf.NewClient(&Token{AccessToken: "revoked", RefreshToken: "r"}, store)
+	is.NoErr(err)
+	_, err = canv.CurrentUser()
+	is.NoErr(err)
+	is.True(revoked)
+	is.Equal(refreshes, 2)
+	is.Equal(store.saves, 1)
+
+	_, err = conf.NewClient(nil, nil)
+	is.True(err != nil)
+}
+
+func TestOAuth2_RefreshContext(t *testing.T) {
+	is := is.New(t)
+	conf, mux, server := testOAuth2(t)
+	defer server.Close()
+	mux.HandleFunc("/login/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		is.NoErr(r.ParseForm())
+		// hang until the client gives up
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+			t.Error("refresh did not use the request's context")
+		}
+	})
+
+	expired := &Token{AccessToken: "old", RefreshToken: "r", Expiry: time.Now().Add(-time.Minute)}
+	canv, err := conf.NewClient(expired, nil)
+	is.NoErr(err)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = canv.WithContext(ctx).CurrentUser()
+	is.True(errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestOAuth2_Revoke(t *testing.T) {
+	is := is.New(t)
+	conf, mux, server := testOAuth2(t)
+	defer server.Close()
+	var called bool
+	mux.HandleFunc("/login/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "DELETE")
+		is.Equal(r.Header.Get("Authorization"), "Bearer one")
+		called = true
+		w.Write([]byte(`{}`))
+	})
+	is.NoErr(conf.Revoke(context.Background(), &Token{AccessToken: "one"}))
+	is.True(called)
+}
@@ -82,6 +82,21 @@ func ContentType(contentType string) Option {
 	return Opt("content_type", contentType)
 }
 
+// PageWorkers sets the number of pages that will be requested at the
+// same time when getting a paginated list. It is never sent to canvas and
+// only changes the number of workers for the one call it is given to.
+func PageWorkers(n int) Option {
+	if n < 1 {
+		n = 1
+	}
+	return pageWorkersOpt(n)
+}
+
+type pageWorkersOpt int
+
+func (pageWorkersOpt) Name() string    { return "" }
+func (pageWorkersOpt) Value() []string { return nil }
+
 // UserOpt creates an Option that should be sent
 // when asking for a user, updating a user, or creating a user.
 func UserOpt(key, val string) Option {
@@ -152,6 +167,9 @@ func (oe optEnc) Encode() string {
 	}
 	var buf strings.Builder
 	for _, o := range oe {
+		if o.Name() == "" {
+			continue
+		}
 		if buf.Len() > 0 {
 			buf.WriteByte('&')
 		}
@@ -0,0 +1,222 @@
+package canvas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harrybrwn/go-querystring/query"
+)
+
+// AssignmentOverride is an assignment override object. An override
+// targets either a list of students, a section, or a group.
+//
+// https://canvas.instructure.com/doc/api/assignments.html#AssignmentOverride
+type AssignmentOverride struct {
+	ID              int       `json:"id" url:"-"`
+	Title           string    `json:"title" url:"title,omitempty"`
+	StudentIds      []int     `json:"student_ids" url:"student_ids,brackets,omitempty"`
+	CourseSectionID int       `json:"course_section_id" url:"course_section_id,omitempty"`
+	GroupID         int       `json:"group_id" url:"group_id,omitempty"`
+	DueAt           time.Time `json:"due_at" url:"due_at,omitempty"`
+	UnlockAt        time.Time `json:"unlock_at" url:"unlock_at,omitempty"`
+	LockAt          time.Time `json:"lock_at" url:"lock_at,omitempty"`
+
+	AssignmentID int       `json:"assignment_id" url:"-"`
+	AllDay       bool      `json:"all_day" url:"-"`
+	AllDayDate   time.Time `json:"all_day_date" url:"-"`
+
+	courseID int
+	client   doer
+}
+
+type assignmentOverrideOptions struct {
+	Override AssignmentOverride `url:"assignment_override"`
+}
+
+// AssignmentOverrides is a list of assignment overrides.
+type AssignmentOverrides []AssignmentOverride
+
+// EncodeValues encodes the overrides using their index
+// (ex. assignment[assignment_overrides][0][due_at]).
+func (ao AssignmentOverrides) EncodeValues(key string, v *url.Values) error {
+	return ao.encode(key, *v, false)
+}
+
+// encode adds the overrides to v. The override and assignment
+// ids are only needed by the batch endpoints.
+func (ao AssignmentOverrides) encode(key string, v url.Values, withIDs bool) error {
+	for i, o := range ao {
+		vals, err := query.Values(&o)
+		if err != nil {
+			return err
+		}
+		prefix := fmt.Sprintf("%s[%d]", key, i)
+		for k, val := range vals {
+			// nested keys like "student_ids[]" need to stay outside of the brackets
+			name, rest := k, ""
+			if j := strings.IndexByte(k, '['); j > 0 {
+				name, rest = k[:j], k[j:]
+			}
+			v[prefix+"["+name+"]"+rest] = val
+		}
+		if withIDs {
+			if o.ID != 0 {
+				v.Set(prefix+"[id]", strconv.Itoa(o.ID))
+			}
+			v.Set(prefix+"[assignment_id]", strconv.Itoa(o.AssignmentID))
+		}
+	}
+	return nil
+}
+
+// ListOverrides will get the assignment's overrides.
+//
+// https://canvas.instructure.com/doc/api/assignments.html#method.assignment_overrides.index
+func (a *Assignment) ListOverrides(opts ...Option) ([]*AssignmentOverride, error) {
+	it := a.OverridesIter(opts...)
+	list := make([]*AssignmentOverride, 0)
+	err := collect(it.iterator, &list)
+	return list, err
+}
+
+// OverridesIter returns an iterator over the assignment's overrides.
+//
+// https://canvas.instructure.com/doc/api/assignments.html#method.assignment_overrides.index
+func (a *Assignment) OverridesIter(opts ...Option) *AssignmentOverrideIterator {
+	path := fmt.Sprintf("/courses/%d/assignments/%d/overrides", a.CourseID, a.ID)
+	return &AssignmentOverrideIterator{newIterator(a.client, path, opts, func(r io.Reader, emit emitFunc) error {
+		list := make([]*AssignmentOverride, 0, defaultPerPage)
+		if err := json.NewDecoder(r).Decode(&list); err != nil {
+			return err
+		}
+		for _, o := range list {
+			o.setclient(a.client, a.CourseID)
+			if err := emit(o); err != nil {
+				return err
+			}
+		}
+		return nil
+	})}
+}
+
+// Override will get one of the assignment's overrides.
+//
+// https://canvas.instructure.com/doc/api/assignments.html#method.assignment_overrides.show
+func (a *Assignment) Override(id int) (*AssignmentOverride, error) {
+	o := &AssignmentOverride{}
+	err := getjson(a.client, o, nil, "/courses/%d/assignments/%d/overrides/%d", a.CourseID, a.ID, id)
+	if err != nil {
+		return nil, err
+	}
+	o.setclient(a.client, a.CourseID)
+	return o, nil
+}
+
+// CreateOverride will create a new override for the assignment.
+//
+// https://canvas.instructure.com/doc/api/assignments.html#method.assignment_overrides.create
+func (a *Assignment) CreateOverride(o AssignmentOverride) (*AssignmentOverride, error) {
+	q, err := query.Values(&assignmentOverrideOptions{o})
+	if err != nil {
+		return nil, err
+	}
+	override := &AssignmentOverride{}
+	path := fmt.Sprintf("/courses/%d/assignments/%d/overrides", a.CourseID, a.ID)
+	if err = send(a.client, post, path, q, override); err != nil {
+		return nil, err
+	}
+	override.setclient(a.client, a.CourseID)
+	return override, nil
+}
+
+// CreateAssignmentOverrides will create overrides for many assignments at once.
+// Each override must have its AssignmentID set.
+//
+// https://canvas.instructure.com/doc/api/assignments.html#method.assignment_overrides.batch_create
+func (c *Course) CreateAssignmentOverrides(overrides []AssignmentOverride) ([]*AssignmentOverride, error) {
+	return c.batchOverrides(post, overrides)
+}
+
+// UpdateAssignmentOverrides will update overrides for many assignments at once.
+// Each override must have its ID and AssignmentID set. Like Update, dates that
+// are zero are left out and will stop being overridden.
+//
+// https://canvas.instructure.com/doc/api/assignments.html#method.assignment_overrides.batch_update
+func (c *Course) UpdateAssignmentOverrides(overrides []AssignmentOverride) ([]*AssignmentOverride, error) {
+	return c.batchOverrides(put, overrides)
+}
+
+func (c *Course) batchOverrides(
+	method func(doer, string, encoder) (*http.Response, error),
+	overrides AssignmentOverrides,
+) ([]*AssignmentOverride, error) {
+	q := url.Values{}
+	if err := overrides.encode("assignment_overrides", q, true); err != nil {
+		return nil, err
+	}
+	list := make([]*AssignmentOverride, 0, len(overrides))
+	if err := send(c.client, method, c.id("/courses/%d/assignments/overrides"), q, &list); err != nil {
+		return nil, err
+	}
+	for _, o := range list {
+		o.setclient(c.client, c.ID)
+	}
+	return list, nil
+}
+
+// WithContext returns a copy of the override that uses ctx.
+func (o *AssignmentOverride) WithContext(ctx context.Context) *AssignmentOverride {
+	cp := *o
+	cp.client = withContext(o.client, ctx)
+	return &cp
+}
+
+// Update will send the override's fields to canvas and replace them
+// with the updated override. Zero values are left out of the request
+// and canvas stops overriding any date that is not sent, so a zero
+// DueAt, UnlockAt, or LockAt will fall back to the assignment's date.
+//
+// https://canvas.instructure.com/doc/api/assignments.html#method.assignment_overrides.update
+func (o *AssignmentOverride) Update() error {
+	q, err := query.Values(&assignmentOverrideOptions{*o})
+	if err != nil {
+		return err
+	}
+	return send(o.client, put, o.path(), q, o)
+}
+
+// Delete will delete the override.
+//
+// https://canvas.instructure.com/doc/api/assignments.html#method.assignment_overrides.destroy
+func (o *AssignmentOverride) Delete() error {
+	resp, err := delete(o.client, o.path(), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (o *AssignmentOverride) path() string {
+	return fmt.Sprintf("/courses/%d/assignments/%d/overrides/%d", o.courseID, o.AssignmentID, o.ID)
+}
+
+func (o *AssignmentOverride) setclient(d doer, courseID int) {
+	o.client = d
+	o.courseID = courseID
+}
+
+// AssignmentOverrideIterator iterates over a paginated list of overrides.
+type AssignmentOverrideIterator struct{ *iterator }
+
+// Value returns the current override.
+func (it *AssignmentOverrideIterator) Value() *AssignmentOverride {
+	o, _ := it.cur.(*AssignmentOverride)
+	return o
+}
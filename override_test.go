@@ -0,0 +1,130 @@
+package canvas
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestCourse_CreateAssignment_Overrides(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	c := &Course{ID: 1, client: cli}
+	due := time.Date(2020, time.March, 2, 23, 59, 0, 0, time.UTC)
+
+	mux.HandleFunc("/api/v1/courses/1/assignments", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		is.Equal(q["assignment[submission_types][]"], []string{"online_upload", "online_url"})
+		is.Equal(q["assignment[assignment_overrides][0][student_ids][]"], []string{"2", "3"})
+		is.Equal(q.Get("assignment[assignment_overrides][0][due_at]"), "2020-03-02T23:59:00Z")
+		is.Equal(q.Get("assignment[assignment_overrides][1][course_section_id]"), "4")
+		is.Equal(len(q["assignment[assignment_overrides][0][course_section_id]"]), 0)
+		is.Equal(q.Get("assignment[notify_of_update]"), "true")
+		w.Write([]byte(`{"id":5,"course_id":1,"has_overrides":true}`))
+	})
+	a, err := c.CreateAssignment(Assignment{
+		Name:            "essay",
+		SubmissionTypes: []string{"online_upload", "online_url"},
+		Overrides: AssignmentOverrides{
+			{StudentIds: []int{2, 3}, DueAt: due},
+			{CourseSectionID: 4, DueAt: due},
+		},
+	}, Opt("assignment[notify_of_update]", true))
+	is.NoErr(err)
+	is.True(a.HasOverrides)
+	is.True(a.client != nil)
+}
+
+func TestAssignment_Overrides(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	a := &Assignment{ID: 5, CourseID: 1, client: cli}
+
+	mux.HandleFunc("/api/v1/courses/1/assignments/5/overrides", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`[{"id":7,"assignment_id":5,"student_ids":[2,3]},{"id":8,"assignment_id":5,"group_id":6}]`))
+		case "POST":
+			q := r.URL.Query()
+			is.Equal(q.Get("assignment_override[group_id]"), "6")
+			is.Equal(q.Get("assignment_override[lock_at]"), "2020-03-05T00:00:00Z")
+			w.Write([]byte(`{"id":8,"assignment_id":5,"group_id":6,"lock_at":"2020-03-05T00:00:00Z"}`))
+		}
+	})
+	var method string
+	mux.HandleFunc("/api/v1/courses/1/assignments/5/overrides/8", func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		if r.Method == "PUT" {
+			q := r.URL.Query()
+			is.Equal(q.Get("assignment_override[title]"), "Group 6")
+			is.Equal(q.Get("assignment_override[lock_at]"), "2020-03-05T00:00:00Z")
+			is.Equal(len(q["assignment_override[due_at]"]), 0)
+		}
+		w.Write([]byte(`{"id":8,"assignment_id":5,"group_id":6,"title":"Group 6"}`))
+	})
+
+	overrides, err := a.ListOverrides()
+	is.NoErr(err)
+	is.Equal(len(overrides), 2)
+	is.Equal(overrides[0].StudentIds, []int{2, 3})
+	is.Equal(overrides[1].path(), "/courses/1/assignments/5/overrides/8")
+
+	o, err := a.CreateOverride(AssignmentOverride{
+		GroupID: 6,
+		LockAt:  time.Date(2020, time.March, 5, 0, 0, 0, 0, time.UTC),
+	})
+	is.NoErr(err)
+	is.Equal(o.ID, 8)
+
+	o.Title = "Group 6"
+	is.NoErr(o.Update())
+	is.Equal(method, "PUT")
+	is.NoErr(o.Delete())
+	is.Equal(method, "DELETE")
+}
+
+func TestCourse_UpdateAssignmentOverrides(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	c := &Course{ID: 1, client: cli}
+	due := time.Date(2020, time.March, 3, 23, 59, 0, 0, time.UTC)
+
+	mux.HandleFunc("/api/v1/courses/1/assignments/overrides", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.Method {
+		case "PUT":
+			is.Equal(q.Get("assignment_overrides[0][id]"), "7")
+			is.Equal(q.Get("assignment_overrides[0][assignment_id]"), "5")
+			is.Equal(q.Get("assignment_overrides[1][id]"), "9")
+			is.Equal(q.Get("assignment_overrides[1][assignment_id]"), "6")
+			is.Equal(q.Get("assignment_overrides[1][due_at]"), "2020-03-03T23:59:00Z")
+			is.Equal(len(q["assignment_overrides[1][lock_at]"]), 0)
+			w.Write([]byte(`[{"id":7,"assignment_id":5},{"id":9,"assignment_id":6}]`))
+		case "POST":
+			is.Equal(len(q["assignment_overrides[0][id]"]), 0)
+			is.Equal(q.Get("assignment_overrides[0][assignment_id]"), "5")
+			is.Equal(q["assignment_overrides[0][student_ids][]"], []string{"2"})
+			w.Write([]byte(`[{"id":10,"assignment_id":5,"student_ids":[2]}]`))
+		}
+	})
+
+	list, err := c.UpdateAssignmentOverrides([]AssignmentOverride{
+		{ID: 7, AssignmentID: 5, CourseSectionID: 4, DueAt: due},
+		{ID: 9, AssignmentID: 6, CourseSectionID: 4, DueAt: due},
+	})
+	is.NoErr(err)
+	is.Equal(len(list), 2)
+	is.Equal(list[1].path(), "/courses/1/assignments/6/overrides/9")
+
+	list, err = c.CreateAssignmentOverrides([]AssignmentOverride{
+		{AssignmentID: 5, StudentIds: []int{2}},
+	})
+	is.NoErr(err)
+	is.Equal(list[0].ID, 10)
+	is.True(list[0].client != nil)
+}
@@ -0,0 +1,366 @@
+package canvas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/harrybrwn/go-querystring/query"
+)
+
+// Page is a wiki page in a course or group.
+//
+// https://canvas.instructure.com/doc/api/pages.html
+type Page struct {
+	PageID int `json:"page_id" url:"-"`
+	// URL is the unique locator for the page
+	// and is made from the page's title.
+	URL       string    `json:"url" url:"-"`
+	Title     string    `json:"title" url:"title,omitempty"`
+	Body      string    `json:"body" url:"body,omitempty"`
+	CreatedAt time.Time `json:"created_at" url:"-"`
+	UpdatedAt time.Time `json:"updated_at" url:"-"`
+	// EditingRoles can be any combination of "teachers",
+	// "students", "members", or "public" separated by commas.
+	EditingRoles     string    `json:"editing_roles" url:"editing_roles,omitempty"`
+	LastEditedBy     *User     `json:"last_edited_by" url:"-"`
+	Published        bool      `json:"published" url:"published,omitempty"`
+	PublishAt        time.Time `json:"publish_at" url:"publish_at,omitempty"`
+	HideFromStudents bool      `json:"hide_from_students" url:"-"`
+	FrontPage        bool      `json:"front_page" url:"front_page,omitempty"`
+	LockedForUser    bool      `json:"locked_for_user" url:"-"`
+	LockExplanation  string    `json:"lock_explanation" url:"-"`
+	// NotifyOfUpdate will notify users that the
+	// page has changed when it is created or updated.
+	NotifyOfUpdate bool `json:"-" url:"notify_of_update,omitempty"`
+
+	// path of the course or group that owns the page
+	ctxPath string
+	client  doer
+}
+
+type pageOptions struct {
+	Page `url:"wiki_page"`
+}
+
+// PageRevision is a revision of a page.
+//
+// https://canvas.instructure.com/doc/api/pages.html#PageRevision
+type PageRevision struct {
+	RevisionID int       `json:"revision_id"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	Latest     bool      `json:"latest"`
+	EditedBy   *User     `json:"edited_by"`
+	// These are only set when the revision is
+	// requested on its own.
+	URL   string `json:"url"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// WithContext returns a copy of the page that uses ctx.
+func (p *Page) WithContext(ctx context.Context) *Page {
+	cp := *p
+	cp.client = withContext(p.client, ctx)
+	return &cp
+}
+
+// Pages returns a channel of the course's pages.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.index
+func (c *Course) Pages(opts ...Option) <-chan *Page {
+	return pagesChannel(c.PagesIter(opts...), c.errorHandler)
+}
+
+// PagesIter returns an iterator over the course's pages.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.index
+func (c *Course) PagesIter(opts ...Option) *PageIterator {
+	return newPageIterator(c.client, c.id("/courses/%d"), opts)
+}
+
+// ListPages returns a slice of the course's pages.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.index
+func (c *Course) ListPages(opts ...Option) ([]*Page, error) {
+	return collectPages(c.PagesIter(opts...))
+}
+
+// Page will get one of the course's pages. The page can be
+// found using either its url (string) or its page id (int).
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.show
+func (c *Course) Page(urlOrID interface{}, opts ...Option) (*Page, error) {
+	return getPage(c.client, c.id("/courses/%d"), urlOrID, opts)
+}
+
+// FrontPage will get the course's front page.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.show_front_page
+func (c *Course) FrontPage() (*Page, error) {
+	return getFrontPage(c.client, c.id("/courses/%d"))
+}
+
+// SetFrontPage will make a page the front page of the course.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.update
+func (c *Course) SetFrontPage(urlOrID interface{}) (*Page, error) {
+	return setFrontPage(c.client, c.id("/courses/%d"), urlOrID)
+}
+
+// CreatePage will create a new page in the course.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.create
+func (c *Course) CreatePage(p Page, opts ...Option) (*Page, error) {
+	return createPage(c.client, c.id("/courses/%d"), p, opts)
+}
+
+// Pages returns a channel of the group's pages.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.index
+func (g *Group) Pages(opts ...Option) <-chan *Page {
+	return pagesChannel(g.PagesIter(opts...), ConcurrentErrorHandler)
+}
+
+// PagesIter returns an iterator over the group's pages.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.index
+func (g *Group) PagesIter(opts ...Option) *PageIterator {
+	return newPageIterator(g.client, g.id("/groups/%d"), opts)
+}
+
+// ListPages returns a slice of the group's pages.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.index
+func (g *Group) ListPages(opts ...Option) ([]*Page, error) {
+	return collectPages(g.PagesIter(opts...))
+}
+
+// Page will get one of the group's pages. The page can be
+// found using either its url (string) or its page id (int).
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.show
+func (g *Group) Page(urlOrID interface{}, opts ...Option) (*Page, error) {
+	return getPage(g.client, g.id("/groups/%d"), urlOrID, opts)
+}
+
+// FrontPage will get the group's front page.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.show_front_page
+func (g *Group) FrontPage() (*Page, error) {
+	return getFrontPage(g.client, g.id("/groups/%d"))
+}
+
+// SetFrontPage will make a page the front page of the group.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.update
+func (g *Group) SetFrontPage(urlOrID interface{}) (*Page, error) {
+	return setFrontPage(g.client, g.id("/groups/%d"), urlOrID)
+}
+
+// CreatePage will create a new page in the group.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.create
+func (g *Group) CreatePage(p Page, opts ...Option) (*Page, error) {
+	return createPage(g.client, g.id("/groups/%d"), p, opts)
+}
+
+// Update will send the page's fields to canvas and replace them with the
+// updated page. Empty fields are left out so Update cannot unpublish the
+// page, use Unpublish instead. Options can be used to send other values
+// that are left out when they are empty.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.update
+func (p *Page) Update(opts ...Option) error {
+	q, err := query.Values(&pageOptions{*p})
+	if err != nil {
+		return err
+	}
+	params(q).Add(opts)
+	return p.send(put, p.path(), q)
+}
+
+// Publish will publish the page.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.update
+func (p *Page) Publish() error {
+	return p.send(put, p.path(), params{"wiki_page[published]": {"true"}})
+}
+
+// Unpublish will unpublish the page.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.update
+func (p *Page) Unpublish() error {
+	return p.send(put, p.path(), params{"wiki_page[published]": {"false"}})
+}
+
+// Delete will delete the page.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.destroy
+func (p *Page) Delete() error {
+	return p.send(delete, p.path(), nil)
+}
+
+// Revisions will get a list of the page's revisions.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.revisions
+func (p *Page) Revisions(opts ...Option) (revs []*PageRevision, err error) {
+	err = getList(p.client, func(r io.Reader) error {
+		page := make([]*PageRevision, 0)
+		if err := json.NewDecoder(r).Decode(&page); err != nil {
+			return err
+		}
+		revs = append(revs, page...)
+		return nil
+	}, p.path()+"/revisions", opts)
+	return revs, err
+}
+
+// Revision will get one revision of the page.
+// A revision id of zero will get the latest revision.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.show_revision
+func (p *Page) Revision(id int) (*PageRevision, error) {
+	path := p.path() + "/revisions/latest"
+	if id != 0 {
+		path = fmt.Sprintf("%s/revisions/%d", p.path(), id)
+	}
+	rev := &PageRevision{}
+	return rev, getjson(p.client, rev, nil, path)
+}
+
+// Revert will set the page's contents back to an earlier revision.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.revert
+func (p *Page) Revert(revisionID int) (*PageRevision, error) {
+	rev := &PageRevision{}
+	err := send(p.client, post, fmt.Sprintf("%s/revisions/%d", p.path(), revisionID), nil, rev)
+	if err != nil {
+		return nil, err
+	}
+	p.Title, p.Body = rev.Title, rev.Body
+	return rev, nil
+}
+
+func (p *Page) send(
+	method func(doer, string, encoder) (*http.Response, error),
+	path string,
+	q encoder,
+) error {
+	if err := send(p.client, method, path, q, p); err != nil {
+		return err
+	}
+	p.setclient(p.client, p.ctxPath)
+	return nil
+}
+
+func (p *Page) path() string {
+	if p.URL != "" {
+		return p.ctxPath + "/pages/" + p.URL
+	}
+	return fmt.Sprintf("%s/pages/page_id:%d", p.ctxPath, p.PageID)
+}
+
+func (p *Page) setclient(d doer, ctxPath string) {
+	p.client = d
+	p.ctxPath = ctxPath
+	if p.LastEditedBy != nil {
+		p.LastEditedBy.client = d
+	}
+}
+
+func pageLocator(urlOrID interface{}) string {
+	switch v := urlOrID.(type) {
+	case int:
+		return fmt.Sprintf("page_id:%d", v)
+	case string:
+		return v
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func getPage(d doer, ctxPath string, urlOrID interface{}, opts []Option) (*Page, error) {
+	p := &Page{}
+	if err := getjson(d, p, optEnc(opts), "%s/pages/%s", ctxPath, pageLocator(urlOrID)); err != nil {
+		return nil, err
+	}
+	p.setclient(d, ctxPath)
+	return p, nil
+}
+
+func getFrontPage(d doer, ctxPath string) (*Page, error) {
+	p := &Page{}
+	if err := getjson(d, p, nil, "%s/front_page", ctxPath); err != nil {
+		return nil, err
+	}
+	p.setclient(d, ctxPath)
+	return p, nil
+}
+
+func setFrontPage(d doer, ctxPath string, urlOrID interface{}) (*Page, error) {
+	p := &Page{}
+	path := fmt.Sprintf("%s/pages/%s", ctxPath, pageLocator(urlOrID))
+	if err := send(d, put, path, params{"wiki_page[front_page]": {"true"}}, p); err != nil {
+		return nil, err
+	}
+	p.setclient(d, ctxPath)
+	return p, nil
+}
+
+func createPage(d doer, ctxPath string, p Page, opts []Option) (*Page, error) {
+	q, err := query.Values(&pageOptions{p})
+	if err != nil {
+		return nil, err
+	}
+	params(q).Add(opts)
+	page := &Page{}
+	if err = send(d, post, ctxPath+"/pages", q, page); err != nil {
+		return nil, err
+	}
+	page.setclient(d, ctxPath)
+	return page, nil
+}
+
+// PageIterator iterates over a paginated list of pages.
+type PageIterator struct{ *iterator }
+
+// Value returns the current page.
+func (it *PageIterator) Value() *Page {
+	p, _ := it.cur.(*Page)
+	return p
+}
+
+func newPageIterator(d doer, ctxPath string, opts []Option) *PageIterator {
+	return &PageIterator{newIterator(d, ctxPath+"/pages", opts, func(r io.Reader, emit emitFunc) error {
+		list := make([]*Page, 0, defaultPerPage)
+		if err := json.NewDecoder(r).Decode(&list); err != nil {
+			return err
+		}
+		for _, p := range list {
+			p.setclient(d, ctxPath)
+			if err := emit(p); err != nil {
+				return err
+			}
+		}
+		return nil
+	})}
+}
+
+func collectPages(it *PageIterator) ([]*Page, error) {
+	pages := make([]*Page, 0)
+	err := collect(it.iterator, &pages)
+	return pages, err
+}
+
+// pagesChannel sends the pages from an iterator over a
+// channel and passes any errors to the error handler.
+func pagesChannel(it *PageIterator, handler errorHandlerFunc) <-chan *Page {
+	it.handler = handler
+	ch := make(chan *Page)
+	stream(it.iterator, ch)
+	return ch
+}
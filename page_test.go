@@ -0,0 +1,136 @@
+package canvas
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestCourse_Pages(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	c := &Course{ID: 1, client: cli}
+
+	mux.HandleFunc("/api/v1/courses/1/pages", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			handlePagingatedList(t, 3, "page.json")(w, r)
+		case "POST":
+			q := r.URL.Query()
+			is.Equal(q.Get("wiki_page[title]"), "My Page Title")
+			is.Equal(q.Get("wiki_page[body]"), "<p>Page Content</p>")
+			is.Equal(q.Get("wiki_page[published]"), "true")
+			is.Equal(len(q["wiki_page[front_page]"]), 0)
+			writeTestFile(t, "page.json", w)
+		}
+	})
+	var path string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		if r.Method == "PUT" {
+			is.Equal(r.URL.Query().Get("wiki_page[front_page]"), "true")
+		}
+		writeTestFile(t, "page.json", w)
+	}
+	mux.HandleFunc("/api/v1/courses/1/pages/my-page-title", handler)
+	mux.HandleFunc("/api/v1/courses/1/pages/page_id:42", handler)
+	mux.HandleFunc("/api/v1/courses/1/front_page", handler)
+
+	pages, err := c.ListPages()
+	is.NoErr(err)
+	is.Equal(len(pages), 3)
+	is.Equal(pages[0].ctxPath, "/courses/1")
+	is.True(pages[0].LastEditedBy.client != nil)
+
+	p, err := c.Page("my-page-title")
+	is.NoErr(err)
+	is.Equal(p.PageID, 42)
+	is.Equal(path, "/api/v1/courses/1/pages/my-page-title")
+	_, err = c.Page(42)
+	is.NoErr(err)
+	is.Equal(path, "/api/v1/courses/1/pages/page_id:42")
+
+	_, err = c.FrontPage()
+	is.NoErr(err)
+	is.Equal(path, "/api/v1/courses/1/front_page")
+	_, err = c.SetFrontPage("my-page-title")
+	is.NoErr(err)
+
+	p, err = c.CreatePage(Page{Title: "My Page Title", Body: "<p>Page Content</p>", Published: true})
+	is.NoErr(err)
+	is.Equal(p.URL, "my-page-title")
+}
+
+func TestPage(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	p := &Page{URL: "my-page-title", Title: "new title", ctxPath: "/groups/17", client: cli}
+
+	var method string
+	var query map[string][]string
+	mux.HandleFunc("/api/v1/groups/17/pages/my-page-title", func(w http.ResponseWriter, r *http.Request) {
+		method, query = r.Method, r.URL.Query()
+		writeTestFile(t, "page.json", w)
+	})
+	mux.HandleFunc("/api/v1/groups/17/pages/my-page-title/revisions", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "GET")
+		w.Write([]byte(`[{"revision_id":3,"latest":true,"edited_by":{"id":2}},{"revision_id":2,"latest":false}]`))
+	})
+	mux.HandleFunc("/api/v1/groups/17/pages/my-page-title/revisions/latest", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "GET")
+		w.Write([]byte(`{"revision_id":3,"latest":true,"title":"new title","body":"<p>new</p>"}`))
+	})
+	mux.HandleFunc("/api/v1/groups/17/pages/my-page-title/revisions/2", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "POST")
+		w.Write([]byte(`{"revision_id":4,"latest":true,"title":"old title","body":"<p>old</p>"}`))
+	})
+
+	is.NoErr(p.Update(Opt("wiki_page[published]", false)))
+	is.Equal(method, "PUT")
+	is.Equal(query["wiki_page[title]"], []string{"new title"})
+	is.Equal(query["wiki_page[published]"], []string{"false"})
+	is.Equal(p.Title, "My Page Title")
+	is.Equal(p.ctxPath, "/groups/17")
+
+	is.NoErr(p.Unpublish())
+	is.Equal(method, "PUT")
+	is.Equal(query["wiki_page[published]"], []string{"false"})
+	is.Equal(len(query["wiki_page[title]"]), 0)
+	is.NoErr(p.Publish())
+	is.Equal(query["wiki_page[published]"], []string{"true"})
+	is.Equal(p.ctxPath, "/groups/17")
+
+	revs, err := p.Revisions()
+	is.NoErr(err)
+	is.Equal(len(revs), 2)
+	is.True(revs[0].Latest)
+
+	rev, err := p.Revision(0)
+	is.NoErr(err)
+	is.Equal(rev.Body, "<p>new</p>")
+
+	rev, err = p.Revert(2)
+	is.NoErr(err)
+	is.Equal(rev.RevisionID, 4)
+	is.Equal(p.Title, "old title")
+
+	is.NoErr(p.Delete())
+	is.Equal(method, "DELETE")
+}
+
+func TestGroup_Pages(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	g := &Group{ID: 17, client: cli}
+	mux.HandleFunc("/api/v1/groups/17/pages", handlePagingatedList(t, 2, "page.json"))
+	n := 0
+	for p := range g.Pages() {
+		is.Equal(p.ctxPath, "/groups/17")
+		n++
+	}
+	is.Equal(n, 2)
+}
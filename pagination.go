@@ -1,7 +1,7 @@
 package canvas
 
 import (
-	"fmt"
+	"context"
 	"io"
 	"net/http"
 	"net/url"
@@ -14,6 +14,8 @@ import (
 
 const (
 	defaultPerPage = 10
+	// number of pages requested at the same time
+	defaultPageWorkers = 8
 )
 
 type sendFunc func(io.Reader) error
@@ -24,17 +26,27 @@ func newPaginatedList(
 	send sendFunc,
 	parameters []Option,
 ) *paginated {
-	if parameters == nil {
-		parameters = []Option{}
+	workers := pageWorkers(d)
+	opts := make([]Option, 0, len(parameters))
+	for _, o := range parameters {
+		if w, ok := o.(pageWorkersOpt); ok {
+			workers = int(w)
+			continue
+		}
+		opts = append(opts, o)
 	}
 	return &paginated{
 		do:      d,
+		ctx:     contextOf(d),
 		path:    path,
-		opts:    parameters,
+		opts:    opts,
 		send:    send,
-		perpage: defaultPerPage,
+		perpage: perPage(d),
+		workers: workers,
 		wg:      new(sync.WaitGroup),
-		errs:    make(chan error),
+		// buffered so that a cancelled context can always be
+		// reported after all the pages have stopped.
+		errs: make(chan error, 1),
 	}
 }
 
@@ -42,48 +54,18 @@ type paginated struct {
 	path string
 	opts []Option
 	do   doer
+	ctx  context.Context
 	send sendFunc
 
 	perpage int
+	workers int
 	errs    chan error
 
 	wg *sync.WaitGroup
 }
 
-type closable interface {
-	Close()
-}
-
 type errorHandlerFunc func(error) error
 
-// Possible bug: ok so this function should be run in a sperate goroutine.
-// When an error is found and the send channel 'ch' is closed, some
-// objects may be sent on the channel after it is closed because it was
-// closed in a seperate goroutine.
-func handleErrs(errs <-chan error, ch closable, handle errorHandlerFunc) {
-	var err error
-	for {
-		select {
-		case e := <-errs:
-			// If e is nil, the error channel has been closed and we stop
-			// otherwise we handle the error.
-			if e != nil {
-				// If the user defined error returns an error then we stop,
-				// if it returns nil, then the user wants to keep going and
-				// handle the error one their side.
-				err = handle(e)
-				if err != nil {
-					goto Stop
-				}
-				continue // don't stop just for one error
-			}
-		Stop:
-			ch.Close() // ch should be a chan wrapped in a type
-			return
-		}
-	}
-}
-
 type pageReader interface {
 	io.Reader
 	Page() int
@@ -102,60 +84,129 @@ func (p *pagereader) Read(b []byte) (int, error) {
 	return p.body.Read(b)
 }
 
-// returns <number of pages>, <first response>
-func (p *paginated) firstReq() (int, *http.Response, error) {
+func (p *paginated) start() <-chan error {
 	resp, err := get(p.do, p.path, p.getPageQuery(1))
 	if err != nil {
-		return -1, nil, err
+		p.errs <- err
+		p.Close()
+		return p.errs
 	}
-	n, err := findlastpage(resp.Header)
+	links, err := newLinkedResource(resp.Header)
 	if err != nil {
-		return -1, nil, err
+		resp.Body.Close()
+		p.errs <- err
+		p.Close()
+		return p.errs
 	}
-	return n, resp, nil
-}
-
-func (p *paginated) start() <-chan error {
-	n, resp, err := p.firstReq() // n pages and first request
-	if err != nil || n == -1 {
-		go func() {
-			p.errs <- err
-			p.Close()
-		}()
+	// Without the last page we cannot request all the
+	// pages at once so we have to follow the next links.
+	if links.Last == nil || links.Last.page < 1 {
+		go p.sequential(resp, links)
 		return p.errs
 	}
-	p.wg.Add(n)
+	n := links.Last.page
+	workers := p.workers
+	if workers > n-1 {
+		workers = n - 1
+	}
+	p.wg.Add(1 + workers)
 
 	go func() {
 		if err = p.send(&pagereader{0, resp.Body}); err != nil {
-			p.errs <- err
+			p.sendErr(err)
 		}
 		resp.Body.Close()
 		p.wg.Done()
 	}()
 	// Already made a request for page 1, so start on 2
-	for page := 2; page <= n; page++ {
-		go func(page int) {
-			defer p.wg.Done()
-			resp, err := get(p.do, p.path, p.getPageQuery(page))
-			if err != nil {
-				p.errs <- err
-				return // stop bc we won't have data to send
+	pages := make(chan int)
+	go func() {
+		defer close(pages)
+		for page := 2; page <= n; page++ {
+			select {
+			case pages <- page:
+			case <-p.ctx.Done():
+				return
 			}
-			// Using page - 1 because pagereaders index from 0 not 1
-			if err = p.send(&pagereader{page - 1, resp.Body}); err != nil {
-				p.errs <- err
+		}
+	}()
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer p.wg.Done()
+			for page := range pages {
+				p.getPage(page)
 			}
-			resp.Body.Close()
-		}(page)
+		}()
 	}
 	go func() {
 		p.wg.Wait()
-		p.Close()
+		p.finish()
 	}()
 	return p.errs
 }
 
+// getPage will request one page and send it.
+func (p *paginated) getPage(page int) {
+	if p.ctx.Err() != nil {
+		return
+	}
+	resp, err := get(p.do, p.path, p.getPageQuery(page))
+	if err != nil {
+		p.sendErr(err)
+		return // stop bc we won't have data to send
+	}
+	// Using page - 1 because pagereaders index from 0 not 1
+	if err = p.send(&pagereader{page - 1, resp.Body}); err != nil {
+		p.sendErr(err)
+	}
+	resp.Body.Close()
+}
+
+// sequential sends each page one at a time by following the
+// rel="next" links starting with the first response.
+func (p *paginated) sequential(resp *http.Response, links *linkedResource) {
+	for page := 0; ; page++ {
+		err := p.send(&pagereader{page, resp.Body})
+		resp.Body.Close()
+		if err != nil {
+			p.sendErr(err)
+		}
+		if links.Next == nil || p.ctx.Err() != nil {
+			break
+		}
+		if resp, err = getLink(p.do, links.Next); err != nil {
+			p.sendErr(err)
+			break
+		}
+		if links, err = newLinkedResource(resp.Header); err != nil {
+			resp.Body.Close()
+			p.sendErr(err)
+			break
+		}
+	}
+	p.finish()
+}
+
+// finish will report a cancelled context and close the error channel.
+func (p *paginated) finish() {
+	if err := p.ctx.Err(); err != nil {
+		select {
+		case p.errs <- err:
+		default: // there is already an error waiting to be received
+		}
+	}
+	p.Close()
+}
+
+// sendErr will send an error to whoever is reading the error channel
+// unless the pager's context has been cancelled.
+func (p *paginated) sendErr(err error) {
+	select {
+	case p.errs <- err:
+	case <-p.ctx.Done():
+	}
+}
+
 func (p *paginated) Close() {
 	close(p.errs)
 }
@@ -169,74 +220,57 @@ func (p *paginated) getPageQuery(page int) params {
 	return q
 }
 
+// getList will call init on each page of a list one
+// page at a time by following the rel="next" links.
 func getList(d doer, init func(io.Reader) error, path string, opts []Option) error {
-	if opts == nil {
-		opts = []Option{}
-	}
-	var (
-		page    = 1
-		perpage = 10
-	)
 	p := params{
-		"page":     {strconv.Itoa(page)},
-		"per_page": {strconv.Itoa(perpage)},
+		"page":     {"1"},
+		"per_page": {strconv.Itoa(perPage(d))},
 	}
 	p.Add(opts)
 	resp, err := get(d, path, p)
-
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-	n, err := findlastpage(resp.Header)
-	if err != nil {
-		return err
-	}
-	if err = init(resp.Body); err != nil {
-		return err
-	}
-
-	for page := 2; page < n; page++ {
-		p := params{
-			"page":     {strconv.Itoa(page)},
-			"per_page": {strconv.Itoa(perpage)},
-		}
-		p.Add(opts)
-		resp, err = get(d, path, p)
+	for {
 		if err != nil {
 			return err
 		}
-		if err = init(resp.Body); err != nil {
-			resp.Body.Close()
-			return err
+		links, err := newLinkedResource(resp.Header)
+		if err == nil {
+			err = init(resp.Body)
 		}
 		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+		if links.Next == nil {
+			return nil
+		}
+		resp, err = getLink(d, links.Next)
 	}
-	return nil
 }
 
-var (
-	resourceRegex = regexp.MustCompile(`<(.*?)>; rel="(.*?)"`)
-	lastpageRegex = regexp.MustCompile(`.*<(.*)[\?&]page=([0-9]*).*>; rel="last"`)
-)
-
-func findlastpage(header http.Header) (int, error) {
-	links := header.Get("Link")
-	if links == "" {
-		return -1, errs.New("no links found in the request header")
-	}
-	parts := lastpageRegex.FindStringSubmatch(links)
-	if len(parts) < 3 {
-		return -1, errs.New("could not find last page")
-	}
-	page, err := strconv.ParseInt(parts[2], 10, 32)
-	return int(page), err
+// getLink will send a request to the url in a link header.
+func getLink(d doer, l *link) (*http.Response, error) {
+	u := *l.url
+	return do(d, &http.Request{
+		Method: "GET",
+		Proto:  "HTTP/1.1",
+		URL:    &u,
+	})
 }
 
+var resourceRegex = regexp.MustCompile(`<(.*?)>; rel="(.*?)"`)
+
+// newLinkedResource parses the Link header. The last and next links are
+// left nil if canvas did not send them, which is the case for large lists
+// and lists that are paginated with bookmarks. A response without a Link
+// header is treated as a list with only one page.
 func newLinkedResource(header http.Header) (*linkedResource, error) {
 	var err error
 	res := &linkedResource{}
 	links := header.Get("Link")
+	if links == "" {
+		return res, nil
+	}
 	parts := resourceRegex.FindAllStringSubmatch(links, -1)
 	m := map[string]*link{}
 
@@ -253,10 +287,8 @@ func newLinkedResource(header http.Header) (*linkedResource, error) {
 	if res.First, ok = m["first"]; !ok {
 		return nil, errs.New("could not find first link")
 	}
-	if res.Last, ok = m["last"]; !ok {
-		return nil, errs.New("could not find last link")
-	}
-	res.Next, _ = m["next"]
+	res.Last = m["last"]
+	res.Next = m["next"]
 	return res, nil
 }
 
@@ -265,7 +297,9 @@ type linkedResource struct {
 }
 
 type link struct {
-	url  *url.URL
+	url *url.URL
+	// page is zero for bookmark links and
+	// any other non-numeric page
 	page int
 }
 
@@ -274,9 +308,11 @@ func newlink(urlstr string) (*link, error) {
 	if err != nil {
 		return nil, err
 	}
+	// pages that are not numbers (ex. "bookmark:..." or "first")
+	// are treated as bookmarks and followed by url
 	page, err := strconv.ParseInt(u.Query().Get("page"), 10, 32)
 	if err != nil {
-		return nil, fmt.Errorf("could not parse page num: %w", err)
+		return &link{url: u}, nil
 	}
 	return &link{
 		url:  u,
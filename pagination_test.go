@@ -2,12 +2,17 @@ package canvas
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/harrybrwn/errs"
 )
@@ -82,8 +87,7 @@ func TestPaginationErrors(t *testing.T) {
 
 	t.Run("send_error", func(t *testing.T) {
 		readCount := 0
-		ch := make(fileChan)
-		send := func(r io.Reader) error {
+		decode := func(r io.Reader, emit emitFunc) error {
 			mu.Lock()
 			readCount++
 			if readCount == 4 {
@@ -94,23 +98,22 @@ func TestPaginationErrors(t *testing.T) {
 			files := make([]*File, 0)
 			err := json.NewDecoder(r).Decode(&files)
 			for _, f := range files {
-				ch <- f
+				if err := emit(f); err != nil {
+					return err
+				}
 			}
 			return err
 		}
-		p := newPaginatedList(
-			c.client, fmt.Sprintf("courses/%d/files/", c.ID),
-			send, nil,
-		)
-		p.perpage = 4
-		go handleErrs(p.start(), ch, func(e error) error {
+		it := newIterator(c.client, fmt.Sprintf("courses/%d/files/", c.ID), nil, decode)
+		it.pager.perpage = 4
+		it.handler = func(e error) error {
 			if e != testerror {
 				t.Error("should only be handling the error I sent")
 			}
 			return nil
-		})
+		}
 		fileCount := 0
-		for range ch {
+		for it.Next() {
 			fileCount++
 		}
 		if readCount != 5 {
@@ -123,8 +126,7 @@ func TestPaginationErrors(t *testing.T) {
 	t.Run("auth_error", func(t *testing.T) {
 		var tok string
 		readCount := 0
-		ch := make(fileChan)
-		send := func(r io.Reader) error {
+		decode := func(r io.Reader, emit emitFunc) error {
 			mu.Lock()
 			readCount++
 			if readCount == 2 {
@@ -135,29 +137,26 @@ func TestPaginationErrors(t *testing.T) {
 			files := make([]*File, 0)
 			err := json.NewDecoder(r).Decode(&files)
 			for _, f := range files {
-				ch <- f
+				if err := emit(f); err != nil {
+					return err
+				}
 			}
 			return err
 		}
-		p := newPaginatedList(
-			c.client,
-			fmt.Sprintf("courses/%d/files/", c.ID),
-			send, nil,
-		)
-		p.perpage = 4
-		go handleErrs(p.start(), ch, func(e error) error {
+		it := &FileIterator{newIterator(c.client, fmt.Sprintf("courses/%d/files/", c.ID), nil, decode)}
+		it.pager.perpage = 4
+		it.handler = func(e error) error {
 			if e == nil {
 				t.Error("expected error")
 			}
-			err, ok := e.(*AuthError)
-			if !ok {
-				t.Errorf("expected an auth error; got %T", err)
+			if !errors.Is(e, ErrUnauthorized) {
+				t.Errorf("expected an unauthorized error; got %v", e)
 			}
 			return nil
-		})
+		}
 		count := 0
-		for f := range ch {
-			if f.ID == 0 {
+		for it.Next() {
+			if it.Value().ID == 0 {
 				t.Error("got bad file id")
 			}
 			count++
@@ -168,3 +167,267 @@ func TestPaginationErrors(t *testing.T) {
 		}
 	})
 }
+
+// handleNextLinks serves a list with n pages that only
+// has rel="next" links like the bookmarked lists.
+func handleNextLinks(t *testing.T, n int, file string, requests *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		pageParam := r.URL.Query().Get("page")
+		if pageParam == "first" {
+			pageParam = "1"
+		}
+		page, err := strconv.Atoi(strings.TrimPrefix(pageParam, "bookmark:"))
+		if err != nil {
+			t.Errorf("bad page: %v", err)
+			return
+		}
+		link := fmt.Sprintf(`<http://%[1]s%[2]s?page=bookmark:%[3]d&per_page=10>; rel="current",<http://%[1]s%[2]s?page=first&per_page=10>; rel="first"`, r.Host, r.URL.Path, page)
+		if page < n {
+			link += fmt.Sprintf(`,<http://%s%s?page=bookmark:%d&per_page=10>; rel="next"`, r.Host, r.URL.Path, page+1)
+		}
+		w.Header().Set("Link", link)
+		w.Write([]byte("["))
+		writeTestFile(t, file, w)
+		w.Write([]byte("]"))
+	}
+}
+
+func TestSequentialPages(t *testing.T) {
+	cli, mux, server := testServer()
+	defer server.Close()
+	u := &User{ID: 2, client: cli}
+	var requests int
+	mux.HandleFunc("/api/v1/users/2/files", handleNextLinks(t, 5, "file.json", &requests))
+
+	files, err := u.ListFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 5 || requests != 5 {
+		t.Errorf("expected 5 files from 5 requests; got %d files and %d requests", len(files), requests)
+	}
+	for _, f := range files {
+		if f.ID != 569 || f.client == nil {
+			t.Error("file not decoded correctly")
+		}
+	}
+
+	requests = 0
+	count := 0
+	for f := range u.Files() {
+		if f.ID != 569 {
+			t.Error("wrong file id")
+		}
+		count++
+	}
+	if count != 5 || requests != 5 {
+		t.Errorf("expected 5 files from 5 requests; got %d files and %d requests", count, requests)
+	}
+}
+
+func TestSequentialPages_Single(t *testing.T) {
+	cli, mux, server := testServer()
+	defer server.Close()
+	u := &User{ID: 2, client: cli}
+	mux.HandleFunc("/api/v1/users/2/files", func(w http.ResponseWriter, r *http.Request) {
+		// no Link header at all
+		w.Write([]byte("["))
+		writeTestFile(t, "file.json", w)
+		w.Write([]byte("]"))
+	})
+	files, err := u.ListFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("expected one file; got %d", len(files))
+	}
+	count := 0
+	for range u.Files() {
+		count++
+	}
+	if count != 1 {
+		t.Errorf("expected one file; got %d", count)
+	}
+}
+
+func TestPageWorkers(t *testing.T) {
+	cli, mux, server := testServer()
+	defer server.Close()
+	var (
+		mu              sync.Mutex
+		inflight, most  int
+		requests, pages = 0, 20
+	)
+	mux.HandleFunc("/api/v1/users/2/files", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		inflight++
+		if inflight > most {
+			most = inflight
+		}
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		w.Header().Set("Link", fmt.Sprintf(`<http://%[1]s%[2]s?page=1&per_page=10>; rel="current",<http://%[1]s%[2]s?page=1&per_page=10>; rel="first",<http://%[1]s%[2]s?page=%[3]d&per_page=10>; rel="last"`, r.Host, r.URL.Path, pages))
+		w.Write([]byte("["))
+		writeTestFile(t, "file.json", w)
+		w.Write([]byte("]"))
+		mu.Lock()
+		inflight--
+		mu.Unlock()
+	})
+	count := func(ch <-chan *File) (n int) {
+		for range ch {
+			n++
+		}
+		return n
+	}
+
+	u := &User{ID: 2, client: cli}
+	if n := count(u.Files(PageWorkers(3))); n != pages {
+		t.Errorf("expected %d files; got %d", pages, n)
+	}
+	if most > 3 {
+		t.Errorf("expected at most 3 requests at once; got %d", most)
+	}
+	if requests != pages {
+		t.Errorf("expected %d requests; got %d", pages, requests)
+	}
+
+	c, err := NewClient("", WithBaseURL(server.URL), WithPageWorkers(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	most, requests = 0, 0
+	u = &User{ID: 2, client: c.client}
+	if n := count(u.Files()); n != pages {
+		t.Errorf("expected %d files; got %d", pages, n)
+	}
+	if most > 2 {
+		t.Errorf("expected at most 2 requests at once; got %d", most)
+	}
+	if _, err = NewClient("", WithPageWorkers(0)); err == nil {
+		t.Error("expected an error for zero page workers")
+	}
+}
+
+func TestIterator(t *testing.T) {
+	cli, mux, server := testServer()
+	defer server.Close()
+	canv := &Canvas{client: cli}
+	pages := 6
+	mux.HandleFunc("/api/v1/users/self/files", func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 4 && r.URL.Query().Get("fail") != "" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[{"message":"not found"}]}`))
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<http://%[1]s%[2]s?page=%[3]d&per_page=10>; rel="current",<http://%[1]s%[2]s?page=1&per_page=10>; rel="first",<http://%[1]s%[2]s?page=%[4]d&per_page=10>; rel="last"`, r.Host, r.URL.Path, page, pages))
+		w.Write([]byte("["))
+		writeTestFile(t, "file.json", w)
+		w.Write([]byte("]"))
+	})
+	// waitStopped makes sure that all of the pager's goroutines have stopped
+	waitStopped := func(it *iterator) {
+		t.Helper()
+		done := make(chan struct{})
+		go func() {
+			for range it.errs {
+			}
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Error("pager goroutines did not stop")
+		}
+	}
+
+	it := canv.FilesIter()
+	n := 0
+	for it.Next() {
+		if it.Value().ID != 569 {
+			t.Error("wrong file id")
+		}
+		n++
+	}
+	if err := it.Err(); err != nil {
+		t.Error(err)
+	}
+	if n != pages {
+		t.Errorf("expected %d files; got %d", pages, n)
+	}
+	if it.Next() || it.Value() != nil {
+		t.Error("iterator should be done")
+	}
+
+	it = canv.FilesIter(Opt("fail", true), PageWorkers(1))
+	n = 0
+	for it.Next() {
+		n++
+	}
+	if !errors.Is(it.Err(), ErrNotFound) {
+		t.Errorf("expected a not found error; got %v", it.Err())
+	}
+	if n >= pages {
+		t.Error("should have stopped at the error")
+	}
+	waitStopped(it.iterator)
+
+	// stopping early
+	it = canv.FilesIter(PageWorkers(2))
+	if !it.Next() {
+		t.Fatal(it.Err())
+	}
+	it.Close()
+	if it.Next() {
+		t.Error("should not continue after being closed")
+	}
+	if it.Err() != nil {
+		t.Error("closing should not be an error")
+	}
+	waitStopped(it.iterator)
+
+	// closing before starting
+	it = canv.FilesIter()
+	it.Close()
+	if it.Next() {
+		t.Error("should not start after being closed")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	it = canv.WithContext(ctx).FilesIter()
+	if it.Next() {
+		t.Error("should not get files with a cancelled context")
+	}
+	if !errors.Is(it.Err(), context.Canceled) {
+		t.Errorf("expected context.Canceled; got %v", it.Err())
+	}
+
+	mux.HandleFunc("/api/v1/users/self/folders", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors":[{"message":"not found"}]}`))
+	})
+	var handled error
+	defer func(h errorHandlerFunc) { ConcurrentErrorHandler = h }(ConcurrentErrorHandler)
+	ConcurrentErrorHandler = func(e error) error { handled = e; return e }
+	for range canv.Folders() {
+		t.Error("should not get any folders")
+	}
+	if handled == nil {
+		t.Error("error handler should have been called")
+	}
+
+	// the default handler closes the channel instead of panicking
+	ConcurrentErrorHandler = defaultErrorHandler
+	mux.HandleFunc("/api/v1/courses/1/rubrics", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	c := &Course{ID: 1, client: canv.client, errorHandler: ConcurrentErrorHandler}
+	for range c.Rubrics() {
+		t.Error("should not get any rubrics")
+	}
+}
@@ -0,0 +1,154 @@
+package canvas
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Workflow states of a Progress.
+const (
+	ProgressQueued    = "queued"
+	ProgressRunning   = "running"
+	ProgressCompleted = "completed"
+	ProgressFailed    = "failed"
+)
+
+// DefaultProgressInterval is the time that Wait
+// will sleep between requests if no interval is given.
+const DefaultProgressInterval = time.Second
+
+// ErrProgressFailed is matched by a *ProgressError using errors.Is.
+var ErrProgressFailed = errors.New("canvas: job failed")
+
+// errNoProgressClient is returned when a progress that was not
+// returned by the api (ex. decoded from json) is polled.
+var errNoProgressClient = errors.New("canvas: progress has no client to poll with")
+
+// Progress is used to track the status of an
+// asynchronous job that canvas is running.
+//
+// https://canvas.instructure.com/doc/api/progress.html
+type Progress struct {
+	ID          int    `json:"id"`
+	ContextID   int    `json:"context_id"`
+	ContextType string `json:"context_type"`
+	UserID      int    `json:"user_id"`
+	Tag         string `json:"tag"`
+	// Completion is the percent of the job that is done.
+	Completion float64 `json:"completion"`
+	// WorkflowState can be any of:
+	//	- "queued"
+	//	- "running"
+	//	- "completed"
+	//	- "failed"
+	WorkflowState string          `json:"workflow_state"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+	Message       string          `json:"message"`
+	Results       json.RawMessage `json:"results"`
+	URL           string          `json:"url"`
+
+	client   doer
+	callback func(*Progress)
+}
+
+// ProgressError is returned by Wait when
+// the job ends in the failed state.
+type ProgressError struct {
+	ID      int
+	Tag     string
+	Message string
+}
+
+func (e *ProgressError) Error() string {
+	msg := fmt.Sprintf("canvas: %s job %d failed", e.Tag, e.ID)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// Is allows a ProgressError to be compared
+// with ErrProgressFailed using errors.Is.
+func (e *ProgressError) Is(target error) bool {
+	return target == ErrProgressFailed
+}
+
+// GetProgress will get a progress object given its id.
+func GetProgress(id int) (*Progress, error) { return ca.GetProgress(id) }
+
+// GetProgress will get a progress object given its id.
+//
+// https://canvas.instructure.com/doc/api/progress.html#method.progress.show
+func (c *Canvas) GetProgress(id int) (*Progress, error) {
+	p := &Progress{ID: id, client: c.client}
+	return p, p.Refresh()
+}
+
+// WithContext returns a copy of the progress that uses ctx.
+func (p *Progress) WithContext(ctx context.Context) *Progress {
+	cp := *p
+	cp.client = withContext(p.client, ctx)
+	return &cp
+}
+
+// Refresh will get the latest status of the job.
+//
+// https://canvas.instructure.com/doc/api/progress.html#method.progress.show
+func (p *Progress) Refresh() error {
+	if p.client == nil {
+		return errNoProgressClient
+	}
+	return getjson(p.client, p, nil, "/progress/%d", p.ID)
+}
+
+// OnUpdate sets a function that is called by Wait each
+// time the completion percentage of the job changes.
+func (p *Progress) OnUpdate(fn func(*Progress)) {
+	p.callback = fn
+}
+
+// Done returns true if the job has either completed or failed.
+func (p *Progress) Done() bool {
+	return p.WorkflowState == ProgressCompleted || p.WorkflowState == ProgressFailed
+}
+
+// Err returns a *ProgressError if the job has failed and nil otherwise.
+func (p *Progress) Err() error {
+	if p.WorkflowState != ProgressFailed {
+		return nil
+	}
+	return &ProgressError{ID: p.ID, Tag: p.Tag, Message: p.Message}
+}
+
+// Wait will poll the progress every interval until the job has
+// finished. A *ProgressError is returned if the job failed and the
+// context's error is returned if ctx is done before the job finishes.
+func (p *Progress) Wait(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		interval = DefaultProgressInterval
+	}
+	d := withContext(p.client, ctx)
+	last := -1.0
+	for {
+		if p.callback != nil && p.Completion != last {
+			last = p.Completion
+			p.callback(p)
+		}
+		if p.Done() {
+			return p.Err()
+		}
+		if p.client == nil {
+			return errNoProgressClient
+		}
+		if err := sleep(ctx, interval); err != nil {
+			return err
+		}
+		if err := getjson(d, p, nil, "/progress/%d", p.ID); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package canvas
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestProgress_Wait(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+
+	states := []string{
+		`{"id":3,"workflow_state":"running","completion":20}`,
+		`{"id":3,"workflow_state":"running","completion":20}`,
+		`{"id":3,"workflow_state":"running","completion":70}`,
+		`{"id":3,"workflow_state":"completed","completion":100}`,
+	}
+	requests := 0
+	mux.HandleFunc("/api/v1/progress/3", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "GET")
+		fmt.Fprint(w, states[requests])
+		requests++
+	})
+
+	p := &Progress{ID: 3, WorkflowState: ProgressQueued, client: cli}
+	var updates []float64
+	p.OnUpdate(func(p *Progress) {
+		updates = append(updates, p.Completion)
+	})
+	is.NoErr(p.Wait(context.Background(), time.Millisecond))
+	is.Equal(requests, 4)
+	is.Equal(updates, []float64{0, 20, 70, 100})
+	is.True(p.Done())
+	is.NoErr(p.Err())
+
+	// already finished jobs should not send any requests
+	is.NoErr(p.Wait(context.Background(), time.Millisecond))
+	is.Equal(requests, 4)
+}
+
+func TestProgress_WaitFailed(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/progress/4", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":4,"tag":"course_batch_update","workflow_state":"failed","message":"something broke"}`)
+	})
+
+	p := &Progress{ID: 4, WorkflowState: ProgressRunning, client: cli}
+	err := p.Wait(context.Background(), time.Millisecond)
+	is.True(errors.Is(err, ErrProgressFailed))
+	var perr *ProgressError
+	is.True(errors.As(err, &perr))
+	is.Equal(perr.Message, "something broke")
+	is.Equal(err.Error(), "canvas: course_batch_update job 4 failed: something broke")
+}
+
+func TestProgress_WaitCancel(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/progress/5", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":5,"workflow_state":"running","completion":10}`)
+	})
+
+	p := &Progress{ID: 5, WorkflowState: ProgressRunning, client: cli}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := p.Wait(ctx, time.Millisecond)
+	is.True(errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestProgress_WaitNoClient(t *testing.T) {
+	is := is.New(t)
+	p := &Progress{}
+	is.NoErr(json.Unmarshal([]byte(`{"id":5,"workflow_state":"running"}`), p))
+	is.Equal(p.Wait(context.Background(), time.Millisecond), errNoProgressClient)
+	is.Equal(p.Refresh(), errNoProgressClient)
+
+	p.WorkflowState = ProgressCompleted
+	is.NoErr(p.Wait(context.Background(), time.Millisecond))
+}
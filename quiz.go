@@ -0,0 +1,603 @@
+package canvas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harrybrwn/go-querystring/query"
+)
+
+// Quiz types.
+const (
+	PracticeQuiz   = "practice_quiz"
+	AssignmentQuiz = "assignment"
+	GradedSurvey   = "graded_survey"
+	Survey         = "survey"
+)
+
+// Quiz is a quiz json response.
+//
+// https://canvas.instructure.com/doc/api/quizzes.html
+type Quiz struct {
+	ID       int       `json:"id" url:"-"`
+	Title    string    `json:"title" url:"title,omitempty"`
+	DueAt    time.Time `json:"due_at" url:"due_at,omitempty"`
+	LockAt   time.Time `json:"lock_at" url:"lock_at,omitempty"`
+	UnlockAt time.Time `json:"unlock_at" url:"unlock_at,omitempty"`
+
+	HTMLURL     string `json:"html_url" url:"-"`
+	MobileURL   string `json:"mobile_url" url:"-"`
+	PreviewURL  string `json:"preview_url" url:"-"`
+	Description string `json:"description" url:"description,omitempty"`
+	// QuizType is one of PracticeQuiz, AssignmentQuiz,
+	// GradedSurvey, or Survey.
+	QuizType          string `json:"quiz_type" url:"quiz_type,omitempty"`
+	AssignmentGroupID int    `json:"assignment_group_id" url:"assignment_group_id,omitempty"`
+	// TimeLimit is the time limit in minutes.
+	TimeLimit      int  `json:"time_limit" url:"time_limit,omitempty"`
+	ShuffleAnswers bool `json:"shuffle_answers" url:"shuffle_answers,omitempty"`
+	// HideResults is "always", "until_after_last_attempt", or empty
+	// if the results are always shown.
+	HideResults                   string    `json:"hide_results" url:"hide_results,omitempty"`
+	ShowCorrectAnswers            bool      `json:"show_correct_answers" url:"show_correct_answers,omitempty"`
+	ShowCorrectAnswersLastAttempt bool      `json:"show_correct_answers_last_attempt" url:"show_correct_answers_last_attempt,omitempty"`
+	ShowCorrectAnswersAt          time.Time `json:"show_correct_answers_at" url:"show_correct_answers_at,omitempty"`
+	HideCorrectAnswersAt          time.Time `json:"hide_correct_answers_at" url:"hide_correct_answers_at,omitempty"`
+	OneTimeResults                bool      `json:"one_time_results" url:"one_time_results,omitempty"`
+	// ScoringPolicy is either "keep_highest" or "keep_latest".
+	ScoringPolicy string `json:"scoring_policy" url:"scoring_policy,omitempty"`
+	// AllowedAttempts is -1 for unlimited attempts.
+	AllowedAttempts      int                `json:"allowed_attempts" url:"allowed_attempts,omitempty"`
+	OneQuestionAtATime   bool               `json:"one_question_at_a_time" url:"one_question_at_a_time,omitempty"`
+	QuestionCount        int                `json:"question_count" url:"-"`
+	PointsPossible       float64            `json:"points_possible" url:"-"`
+	CantGoBack           bool               `json:"cant_go_back" url:"cant_go_back,omitempty"`
+	AccessCode           string             `json:"access_code" url:"access_code,omitempty"`
+	IPFilter             string             `json:"ip_filter" url:"ip_filter,omitempty"`
+	Published            bool               `json:"published" url:"published,omitempty"`
+	Unpublishable        bool               `json:"unpublishable" url:"-"`
+	LockedForUser        bool               `json:"locked_for_user" url:"-"`
+	LockInfo             interface{}        `json:"lock_info" url:"-"`
+	LockExplanation      string             `json:"lock_explanation" url:"-"`
+	SpeedgraderURL       string             `json:"speedgrader_url" url:"-"`
+	QuizExtensionsURL    string             `json:"quiz_extensions_url" url:"-"`
+	Permissions          QuizPermissions    `json:"permissions" url:"-"`
+	AllDates             []QuizDate         `json:"all_dates" url:"-"`
+	VersionNumber        int                `json:"version_number" url:"-"`
+	QuestionTypes        []QuizQuestionType `json:"question_types" url:"-"`
+	AnonymousSubmissions bool               `json:"anonymous_submissions" url:"-"`
+
+	courseID int
+	client   doer
+}
+
+type quizOptions struct {
+	Quiz `url:"quiz"`
+}
+
+// QuizPermissions is the permissions for a quiz.
+type QuizPermissions struct {
+	Read           bool `json:"read"`
+	Submit         bool `json:"submit"`
+	Create         bool `json:"create"`
+	Manage         bool `json:"manage"`
+	ReadStatistics bool `json:"read_statistics"`
+	ReviewGrades   bool `json:"review_grades"`
+	Update         bool `json:"update"`
+}
+
+// QuizDate is one of the due dates of a quiz.
+type QuizDate struct {
+	ID       int       `json:"id"`
+	Base     bool      `json:"base"`
+	Title    string    `json:"title"`
+	DueAt    time.Time `json:"due_at"`
+	UnlockAt time.Time `json:"unlock_at"`
+	LockAt   time.Time `json:"lock_at"`
+}
+
+// QuizQuestionType is the kind of question in a quiz.
+type QuizQuestionType string
+
+// Quiz question types.
+const (
+	MultipleChoiceQuestion       QuizQuestionType = "multiple_choice_question"
+	TrueFalseQuestion            QuizQuestionType = "true_false_question"
+	ShortAnswerQuestion          QuizQuestionType = "short_answer_question"
+	FillInMultipleBlanksQuestion QuizQuestionType = "fill_in_multiple_blanks_question"
+	MultipleAnswersQuestion      QuizQuestionType = "multiple_answers_question"
+	MultipleDropdownsQuestion    QuizQuestionType = "multiple_dropdowns_question"
+	MatchingQuestion             QuizQuestionType = "matching_question"
+	NumericalQuestion            QuizQuestionType = "numerical_question"
+	CalculatedQuestion           QuizQuestionType = "calculated_question"
+	EssayQuestion                QuizQuestionType = "essay_question"
+	FileUploadQuestion           QuizQuestionType = "file_upload_question"
+	TextOnlyQuestion             QuizQuestionType = "text_only_question"
+)
+
+// QuizQuestion is a question in a quiz.
+//
+// https://canvas.instructure.com/doc/api/quiz_questions.html
+type QuizQuestion struct {
+	ID          int `json:"id" url:"-"`
+	QuizID      int `json:"quiz_id" url:"-"`
+	QuizGroupID int `json:"quiz_group_id" url:"quiz_group_id,omitempty"`
+	Position    int `json:"position" url:"position,omitempty"`
+
+	QuestionName      string           `json:"question_name" url:"question_name,omitempty"`
+	QuestionType      QuizQuestionType `json:"question_type" url:"question_type,omitempty"`
+	QuestionText      string           `json:"question_text" url:"question_text,omitempty"`
+	PointsPossible    float64          `json:"points_possible" url:"points_possible,omitempty"`
+	CorrectComments   string           `json:"correct_comments" url:"correct_comments,omitempty"`
+	IncorrectComments string           `json:"incorrect_comments" url:"incorrect_comments,omitempty"`
+	NeutralComments   string           `json:"neutral_comments" url:"neutral_comments,omitempty"`
+	TextAfterAnswers  string           `json:"text_after_answers" url:"text_after_answers,omitempty"`
+	Answers           QuizAnswers      `json:"answers" url:"answers,omitempty"`
+	// Matches holds the right hand side of a matching question.
+	Matches []QuizMatch `json:"matches" url:"-"`
+
+	courseID int
+	client   doer
+}
+
+type quizQuestionOptions struct {
+	Question QuizQuestion `url:"question"`
+}
+
+// QuizAnswer is a possible answer to a quiz question. Which
+// fields are used depends on the type of question.
+type QuizAnswer struct {
+	ID       int    `json:"id" url:"id,omitempty"`
+	Text     string `json:"text" url:"answer_text,omitempty"`
+	HTML     string `json:"html" url:"answer_html,omitempty"`
+	Weight   int    `json:"weight" url:"answer_weight"`
+	Comments string `json:"comments" url:"answer_comments,omitempty"`
+
+	// BlankID is the blank that the answer fills in for fill in
+	// multiple blanks and multiple dropdown questions.
+	BlankID string `json:"blank_id" url:"blank_id,omitempty"`
+
+	// Matching questions
+	Left                           string `json:"left" url:"answer_match_left,omitempty"`
+	Right                          string `json:"right" url:"answer_match_right,omitempty"`
+	MatchingAnswerIncorrectMatches string `json:"-" url:"matching_answer_incorrect_matches,omitempty"`
+
+	// Numerical questions. NumericalAnswerType is "exact_answer",
+	// "range_answer", or "precision_answer".
+	NumericalAnswerType string  `json:"numerical_answer_type" url:"numerical_answer_type,omitempty"`
+	Exact               float64 `json:"exact" url:"exact,omitempty"`
+	Margin              float64 `json:"margin" url:"margin,omitempty"`
+	Approximate         float64 `json:"approximate" url:"approximate,omitempty"`
+	Precision           int     `json:"precision" url:"precision,omitempty"`
+	Start               float64 `json:"start" url:"start,omitempty"`
+	End                 float64 `json:"end" url:"end,omitempty"`
+}
+
+// QuizAnswers is a list of answers to a quiz question.
+type QuizAnswers []QuizAnswer
+
+// EncodeValues encodes the answers using their
+// index (ex. question[answers][0][answer_text]).
+func (qa QuizAnswers) EncodeValues(key string, v *url.Values) error {
+	for i, a := range qa {
+		vals, err := query.Values(&a)
+		if err != nil {
+			return err
+		}
+		for k, val := range vals {
+			(*v)[fmt.Sprintf("%s[%d][%s]", key, i, k)] = val
+		}
+	}
+	return nil
+}
+
+// QuizMatch is the right hand side of a matching question.
+type QuizMatch struct {
+	MatchID int    `json:"match_id"`
+	Text    string `json:"text"`
+}
+
+// QuizGroup is a group of questions in a quiz where
+// only a few of the questions are picked for each attempt.
+//
+// https://canvas.instructure.com/doc/api/quiz_question_groups.html
+type QuizGroup struct {
+	ID     int    `json:"id" url:"-"`
+	QuizID int    `json:"quiz_id" url:"-"`
+	Name   string `json:"name" url:"name,omitempty"`
+	// PickCount is the number of questions to pick for each attempt.
+	PickCount      int     `json:"pick_count" url:"pick_count,omitempty"`
+	QuestionPoints float64 `json:"question_points" url:"question_points,omitempty"`
+	// AssessmentQuestionBankID is set when the questions
+	// are picked from a question bank.
+	AssessmentQuestionBankID int `json:"assessment_question_bank_id" url:"assessment_question_bank_id,omitempty"`
+	Position                 int `json:"position" url:"position,omitempty"`
+
+	courseID int
+	client   doer
+}
+
+type quizGroupOptions struct {
+	Group QuizGroup `url:"quiz_groups[]"`
+}
+
+// QuizOrderItem is a question or group used to reorder a quiz.
+type QuizOrderItem struct {
+	ID int
+	// Type is either "question" or "group"
+	Type string
+}
+
+// Quizzes will get all the course quizzes
+//
+// https://canvas.instructure.com/doc/api/quizzes.html#method.quizzes/quizzes_api.index
+func (c *Course) Quizzes(opts ...Option) ([]*Quiz, error) {
+	return collectQuizzes(c.QuizzesIter(opts...))
+}
+
+// QuizzesIter returns an iterator over the course quizzes.
+//
+// https://canvas.instructure.com/doc/api/quizzes.html#method.quizzes/quizzes_api.index
+func (c *Course) QuizzesIter(opts ...Option) *QuizIterator {
+	return &QuizIterator{newIterator(c.client, c.id("/courses/%d/quizzes"), opts, func(r io.Reader, emit emitFunc) error {
+		list := make([]*Quiz, 0, defaultPerPage)
+		if err := json.NewDecoder(r).Decode(&list); err != nil {
+			return err
+		}
+		for _, q := range list {
+			q.setclient(c.client, c.ID)
+			if err := emit(q); err != nil {
+				return err
+			}
+		}
+		return nil
+	})}
+}
+
+// Quiz will return a quiz given a quiz id.
+//
+// https://canvas.instructure.com/doc/api/quizzes.html#method.quizzes/quizzes_api.show
+func (c *Course) Quiz(id int, opts ...Option) (*Quiz, error) {
+	q := &Quiz{}
+	if err := getjson(c.client, q, optEnc(opts), "/courses/%d/quizzes/%d", c.ID, id); err != nil {
+		return nil, err
+	}
+	q.setclient(c.client, c.ID)
+	return q, nil
+}
+
+// CreateQuiz will create a new quiz in the course.
+//
+// https://canvas.instructure.com/doc/api/quizzes.html#method.quizzes/quizzes_api.create
+func (c *Course) CreateQuiz(q Quiz, opts ...Option) (*Quiz, error) {
+	vals, err := query.Values(&quizOptions{q})
+	if err != nil {
+		return nil, err
+	}
+	params(vals).Add(opts)
+	quiz := &Quiz{}
+	if err = send(c.client, post, c.id("/courses/%d/quizzes"), vals, quiz); err != nil {
+		return nil, err
+	}
+	quiz.setclient(c.client, c.ID)
+	return quiz, nil
+}
+
+// WithContext returns a copy of the quiz that uses ctx.
+func (q *Quiz) WithContext(ctx context.Context) *Quiz {
+	cp := *q
+	cp.client = withContext(q.client, ctx)
+	return &cp
+}
+
+// Update will send the quiz's fields to canvas and replace them with the
+// updated quiz. Options can be used to send values that are left out when
+// they are empty (ex. Opt("quiz[time_limit]", "")).
+//
+// https://canvas.instructure.com/doc/api/quizzes.html#method.quizzes/quizzes_api.update
+func (q *Quiz) Update(opts ...Option) error {
+	vals, err := query.Values(&quizOptions{*q})
+	if err != nil {
+		return err
+	}
+	params(vals).Add(opts)
+	return q.send(put, q.path(), vals)
+}
+
+// Delete will delete the quiz.
+//
+// https://canvas.instructure.com/doc/api/quizzes.html#method.quizzes/quizzes_api.destroy
+func (q *Quiz) Delete() error {
+	resp, err := delete(q.client, q.path(), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Publish will publish the quiz so that students can see it.
+func (q *Quiz) Publish() error {
+	return q.send(put, q.path(), params{"quiz[published]": {"true"}})
+}
+
+// Unpublish will unpublish the quiz.
+func (q *Quiz) Unpublish() error {
+	return q.send(put, q.path(), params{"quiz[published]": {"false"}})
+}
+
+// Reorder will change the order of the quiz's questions and groups.
+//
+// https://canvas.instructure.com/doc/api/quizzes.html#method.quizzes/quizzes_api.reorder
+func (q *Quiz) Reorder(items ...QuizOrderItem) error {
+	resp, err := post(q.client, q.path()+"/reorder", quizOrder(items))
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Questions will get the quiz's questions.
+//
+// https://canvas.instructure.com/doc/api/quiz_questions.html#method.quizzes/quiz_questions.index
+func (q *Quiz) Questions(opts ...Option) ([]*QuizQuestion, error) {
+	return collectQuizQuestions(q.QuestionsIter(opts...))
+}
+
+// QuestionsIter returns an iterator over the quiz's questions.
+//
+// https://canvas.instructure.com/doc/api/quiz_questions.html#method.quizzes/quiz_questions.index
+func (q *Quiz) QuestionsIter(opts ...Option) *QuizQuestionIterator {
+	return &QuizQuestionIterator{newIterator(q.client, q.path()+"/questions", opts, func(r io.Reader, emit emitFunc) error {
+		list := make([]*QuizQuestion, 0, defaultPerPage)
+		if err := json.NewDecoder(r).Decode(&list); err != nil {
+			return err
+		}
+		for _, qq := range list {
+			qq.setclient(q.client, q.courseID)
+			if err := emit(qq); err != nil {
+				return err
+			}
+		}
+		return nil
+	})}
+}
+
+// Question will get one of the quiz's questions.
+//
+// https://canvas.instructure.com/doc/api/quiz_questions.html#method.quizzes/quiz_questions.show
+func (q *Quiz) Question(id int, opts ...Option) (*QuizQuestion, error) {
+	qq := &QuizQuestion{}
+	if err := getjson(q.client, qq, optEnc(opts), "%s/questions/%d", q.path(), id); err != nil {
+		return nil, err
+	}
+	qq.setclient(q.client, q.courseID)
+	return qq, nil
+}
+
+// CreateQuestion will add a new question to the quiz.
+//
+// https://canvas.instructure.com/doc/api/quiz_questions.html#method.quizzes/quiz_questions.create
+func (q *Quiz) CreateQuestion(question QuizQuestion, opts ...Option) (*QuizQuestion, error) {
+	vals, err := query.Values(&quizQuestionOptions{question})
+	if err != nil {
+		return nil, err
+	}
+	params(vals).Add(opts)
+	qq := &QuizQuestion{}
+	if err = send(q.client, post, q.path()+"/questions", vals, qq); err != nil {
+		return nil, err
+	}
+	qq.setclient(q.client, q.courseID)
+	return qq, nil
+}
+
+// Group will get one of the quiz's question groups.
+//
+// https://canvas.instructure.com/doc/api/quiz_question_groups.html#method.quizzes/quiz_groups.show
+func (q *Quiz) Group(id int) (*QuizGroup, error) {
+	g := &QuizGroup{}
+	if err := getjson(q.client, g, nil, "%s/groups/%d", q.path(), id); err != nil {
+		return nil, err
+	}
+	g.client, g.courseID = q.client, q.courseID
+	return g, nil
+}
+
+// CreateGroup will add a new question group to the quiz.
+//
+// https://canvas.instructure.com/doc/api/quiz_question_groups.html#method.quizzes/quiz_groups.create
+func (q *Quiz) CreateGroup(g QuizGroup) (*QuizGroup, error) {
+	group := &QuizGroup{client: q.client, courseID: q.courseID}
+	return group, group.send(post, q.path()+"/groups", g)
+}
+
+func (q *Quiz) path() string {
+	return fmt.Sprintf("/courses/%d/quizzes/%d", q.courseID, q.ID)
+}
+
+func (q *Quiz) send(
+	method func(doer, string, encoder) (*http.Response, error),
+	path string,
+	vals encoder,
+) error {
+	if err := send(q.client, method, path, vals, q); err != nil {
+		return err
+	}
+	q.setclient(q.client, q.courseID)
+	return nil
+}
+
+func (q *Quiz) setclient(d doer, courseID int) {
+	q.client = d
+	q.courseID = courseID
+}
+
+// WithContext returns a copy of the question that uses ctx.
+func (qq *QuizQuestion) WithContext(ctx context.Context) *QuizQuestion {
+	cp := *qq
+	cp.client = withContext(qq.client, ctx)
+	return &cp
+}
+
+// Update will send the question's fields to canvas
+// and replace them with the updated question.
+//
+// https://canvas.instructure.com/doc/api/quiz_questions.html#method.quizzes/quiz_questions.update
+func (qq *QuizQuestion) Update(opts ...Option) error {
+	vals, err := query.Values(&quizQuestionOptions{*qq})
+	if err != nil {
+		return err
+	}
+	params(vals).Add(opts)
+	return send(qq.client, put, qq.path(), vals, qq)
+}
+
+// Delete will remove the question from the quiz.
+//
+// https://canvas.instructure.com/doc/api/quiz_questions.html#method.quizzes/quiz_questions.destroy
+func (qq *QuizQuestion) Delete() error {
+	resp, err := delete(qq.client, qq.path(), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (qq *QuizQuestion) path() string {
+	return fmt.Sprintf("/courses/%d/quizzes/%d/questions/%d", qq.courseID, qq.QuizID, qq.ID)
+}
+
+func (qq *QuizQuestion) setclient(d doer, courseID int) {
+	qq.client = d
+	qq.courseID = courseID
+}
+
+// WithContext returns a copy of the question group that uses ctx.
+func (g *QuizGroup) WithContext(ctx context.Context) *QuizGroup {
+	cp := *g
+	cp.client = withContext(g.client, ctx)
+	return &cp
+}
+
+// Update will send the group's fields to canvas
+// and replace them with the updated group.
+//
+// https://canvas.instructure.com/doc/api/quiz_question_groups.html#method.quizzes/quiz_groups.update
+func (g *QuizGroup) Update() error {
+	return g.send(put, g.path(), *g)
+}
+
+// Delete will delete the group. The group's questions
+// are not deleted and are moved back into the quiz.
+//
+// https://canvas.instructure.com/doc/api/quiz_question_groups.html#method.quizzes/quiz_groups.destroy
+func (g *QuizGroup) Delete() error {
+	resp, err := delete(g.client, g.path(), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Reorder will change the order of the questions in the group.
+//
+// https://canvas.instructure.com/doc/api/quiz_question_groups.html#method.quizzes/quiz_groups.reorder
+func (g *QuizGroup) Reorder(questionIDs ...int) error {
+	items := make(quizOrder, len(questionIDs))
+	for i, id := range questionIDs {
+		items[i] = QuizOrderItem{ID: id, Type: "question"}
+	}
+	resp, err := post(g.client, g.path()+"/reorder", items)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (g *QuizGroup) path() string {
+	return fmt.Sprintf("/courses/%d/quizzes/%d/groups/%d", g.courseID, g.QuizID, g.ID)
+}
+
+// send will send the group and replace it with the first
+// group in canvas' response.
+func (g *QuizGroup) send(
+	method func(doer, string, encoder) (*http.Response, error),
+	path string,
+	group QuizGroup,
+) error {
+	vals, err := query.Values(&quizGroupOptions{group})
+	if err != nil {
+		return err
+	}
+	var res struct {
+		Groups []QuizGroup `json:"quiz_groups"`
+	}
+	if err = send(g.client, method, path, vals, &res); err != nil {
+		return err
+	}
+	if len(res.Groups) == 0 {
+		return fmt.Errorf("no quiz group returned from %s", path)
+	}
+	client, courseID := g.client, g.courseID
+	*g = res.Groups[0]
+	g.client, g.courseID = client, courseID
+	return nil
+}
+
+// quizOrder encodes a list of order items in order. The items cannot be
+// encoded as url.Values because the "order[][id]" and "order[][type]" keys
+// would be sorted and the ids would be separated from their types.
+type quizOrder []QuizOrderItem
+
+func (qo quizOrder) Encode() string {
+	var (
+		b       strings.Builder
+		idKey   = url.QueryEscape("order[][id]")
+		typeKey = url.QueryEscape("order[][type]")
+	)
+	for i, item := range qo {
+		if i > 0 {
+			b.WriteByte('&')
+		}
+		b.WriteString(idKey + "=" + strconv.Itoa(item.ID))
+		b.WriteString("&" + typeKey + "=" + url.QueryEscape(item.Type))
+	}
+	return b.String()
+}
+
+// QuizIterator iterates over a paginated list of quizzes.
+type QuizIterator struct{ *iterator }
+
+// Value returns the current quiz.
+func (it *QuizIterator) Value() *Quiz {
+	q, _ := it.cur.(*Quiz)
+	return q
+}
+
+func collectQuizzes(it *QuizIterator) ([]*Quiz, error) {
+	list := make([]*Quiz, 0)
+	err := collect(it.iterator, &list)
+	return list, err
+}
+
+// QuizQuestionIterator iterates over a paginated list of quiz questions.
+type QuizQuestionIterator struct{ *iterator }
+
+// Value returns the current question.
+func (it *QuizQuestionIterator) Value() *QuizQuestion {
+	q, _ := it.cur.(*QuizQuestion)
+	return q
+}
+
+func collectQuizQuestions(it *QuizQuestionIterator) ([]*QuizQuestion, error) {
+	list := make([]*QuizQuestion, 0)
+	err := collect(it.iterator, &list)
+	return list, err
+}
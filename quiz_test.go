@@ -0,0 +1,173 @@
+package canvas
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestCourse_Quizzes(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	c := &Course{ID: 1, client: cli}
+
+	mux.HandleFunc("/api/v1/courses/1/quizzes", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`[{"id":3,"title":"Quiz 1","points_possible":2.5},{"id":4,"title":"Quiz 2"}]`))
+		case "POST":
+			q := r.URL.Query()
+			is.Equal(q.Get("quiz[title]"), "Midterm")
+			is.Equal(q.Get("quiz[quiz_type]"), AssignmentQuiz)
+			is.Equal(q.Get("quiz[time_limit]"), "60")
+			is.Equal(len(q["quiz[published]"]), 0)
+			w.Write([]byte(`{"id":5,"title":"Midterm","quiz_type":"assignment","time_limit":60}`))
+		}
+	})
+	var query map[string][]string
+	mux.HandleFunc("/api/v1/courses/1/quizzes/5", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`{"id":5,"title":"Midterm","all_dates":[{"base":true,"due_at":"2020-03-01T09:00:00Z"}]}`))
+		case "PUT":
+			query = r.URL.Query()
+			w.Write([]byte(`{"id":5,"title":"Midterm","published":` + query["quiz[published]"][0] + `}`))
+		case "DELETE":
+			w.Write([]byte(`{"id":5}`))
+		}
+	})
+	mux.HandleFunc("/api/v1/courses/1/quizzes/5/reorder", func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(r.Method, "POST")
+		is.Equal(r.URL.RawQuery, "order%5B%5D%5Bid%5D=2&order%5B%5D%5Btype%5D=group&order%5B%5D%5Bid%5D=7&order%5B%5D%5Btype%5D=question")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	quizzes, err := c.Quizzes()
+	is.NoErr(err)
+	is.Equal(len(quizzes), 2)
+	is.Equal(quizzes[0].PointsPossible, 2.5)
+	is.Equal(quizzes[0].path(), "/courses/1/quizzes/3")
+
+	quiz, err := c.CreateQuiz(Quiz{Title: "Midterm", QuizType: AssignmentQuiz, TimeLimit: 60})
+	is.NoErr(err)
+	is.Equal(quiz.ID, 5)
+
+	quiz, err = c.Quiz(5)
+	is.NoErr(err)
+	is.Equal(len(quiz.AllDates), 1)
+	is.True(quiz.AllDates[0].Base)
+
+	is.NoErr(quiz.Publish())
+	is.True(quiz.Published)
+	is.NoErr(quiz.Unpublish())
+	is.Equal(query["quiz[published]"], []string{"false"})
+	is.True(!quiz.Published)
+
+	is.NoErr(quiz.Reorder(
+		QuizOrderItem{ID: 2, Type: "group"},
+		QuizOrderItem{ID: 7, Type: "question"},
+	))
+	is.NoErr(quiz.Delete())
+}
+
+func TestQuiz_Questions(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	quiz := &Quiz{ID: 5, courseID: 1, client: cli}
+
+	mux.HandleFunc("/api/v1/courses/1/quizzes/5/questions", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`[{"id":7,"quiz_id":5,"question_type":"essay_question"}]`))
+		case "POST":
+			q := r.URL.Query()
+			is.Equal(q.Get("question[question_type]"), string(MultipleChoiceQuestion))
+			is.Equal(q.Get("question[points_possible]"), "2")
+			is.Equal(q.Get("question[answers][0][answer_text]"), "4")
+			is.Equal(q.Get("question[answers][0][answer_weight]"), "100")
+			is.Equal(q.Get("question[answers][1][answer_text]"), "5")
+			is.Equal(q.Get("question[answers][1][answer_weight]"), "0")
+			w.Write([]byte(`{"id":8,"quiz_id":5,"question_type":"multiple_choice_question",
+				"answers":[{"id":1,"text":"4","weight":100},{"id":2,"text":"5","weight":0}]}`))
+		}
+	})
+	var method string
+	mux.HandleFunc("/api/v1/courses/1/quizzes/5/questions/8", func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		if r.Method == "PUT" {
+			is.Equal(r.URL.Query().Get("question[question_name]"), "Addition")
+			is.Equal(r.URL.Query().Get("question[answers][1][id]"), "2")
+		}
+		w.Write([]byte(`{"id":8,"quiz_id":5,"question_name":"Addition"}`))
+	})
+
+	questions, err := quiz.Questions()
+	is.NoErr(err)
+	is.Equal(len(questions), 1)
+	is.Equal(questions[0].QuestionType, EssayQuestion)
+	is.Equal(questions[0].path(), "/courses/1/quizzes/5/questions/7")
+
+	question, err := quiz.CreateQuestion(QuizQuestion{
+		QuestionType:   MultipleChoiceQuestion,
+		QuestionText:   "2 + 2",
+		PointsPossible: 2,
+		Answers: QuizAnswers{
+			{Text: "4", Weight: 100},
+			{Text: "5"},
+		},
+	})
+	is.NoErr(err)
+	is.Equal(question.ID, 8)
+	is.Equal(len(question.Answers), 2)
+	is.Equal(question.Answers[0].Weight, 100)
+
+	question.QuestionName = "Addition"
+	is.NoErr(question.Update())
+	is.Equal(method, "PUT")
+	is.Equal(question.QuestionName, "Addition")
+	is.NoErr(question.Delete())
+	is.Equal(method, "DELETE")
+}
+
+func TestQuiz_Groups(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	quiz := &Quiz{ID: 5, courseID: 1, client: cli}
+
+	mux.HandleFunc("/api/v1/courses/1/quizzes/5/groups", func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(r.Method, "POST")
+		q := r.URL.Query()
+		is.Equal(q.Get("quiz_groups[][name]"), "Pool")
+		is.Equal(q.Get("quiz_groups[][pick_count]"), "2")
+		w.Write([]byte(`{"quiz_groups":[{"id":3,"quiz_id":5,"name":"Pool","pick_count":2}]}`))
+	})
+	mux.HandleFunc("/api/v1/courses/1/quizzes/5/groups/3", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			is.Equal(r.URL.Query().Get("quiz_groups[][pick_count]"), "3")
+			w.Write([]byte(`{"quiz_groups":[{"id":3,"quiz_id":5,"name":"Pool","pick_count":3}]}`))
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	mux.HandleFunc("/api/v1/courses/1/quizzes/5/groups/3/reorder", func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(r.URL.RawQuery, "order%5B%5D%5Bid%5D=9&order%5B%5D%5Btype%5D=question")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	g, err := quiz.CreateGroup(QuizGroup{Name: "Pool", PickCount: 2})
+	is.NoErr(err)
+	is.Equal(g.ID, 3)
+	is.Equal(g.path(), "/courses/1/quizzes/5/groups/3")
+
+	g.PickCount = 3
+	is.NoErr(g.Update())
+	is.Equal(g.PickCount, 3)
+	is.True(g.client != nil)
+	is.NoErr(g.Reorder(9))
+	is.NoErr(g.Delete())
+}
@@ -0,0 +1,451 @@
+package canvas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/harrybrwn/errs"
+)
+
+// QuizSubmission is an attempt at a quiz.
+//
+// https://canvas.instructure.com/doc/api/quiz_submissions.html
+type QuizSubmission struct {
+	ID           int       `json:"id"`
+	QuizID       int       `json:"quiz_id"`
+	UserID       int       `json:"user_id"`
+	SubmissionID int       `json:"submission_id"`
+	StartedAt    time.Time `json:"started_at"`
+	FinishedAt   time.Time `json:"finished_at"`
+	EndAt        time.Time `json:"end_at"`
+	Attempt      int       `json:"attempt"`
+	// ExtraAttempts and ExtraTime are the student's extensions.
+	ExtraAttempts    int  `json:"extra_attempts"`
+	ExtraTime        int  `json:"extra_time"`
+	ManuallyUnlocked bool `json:"manually_unlocked"`
+	// TimeSpent is the number of seconds spent on the attempt.
+	TimeSpent          int     `json:"time_spent"`
+	Score              float64 `json:"score"`
+	ScoreBeforeRegrade float64 `json:"score_before_regrade"`
+	KeptScore          float64 `json:"kept_score"`
+	FudgePoints        float64 `json:"fudge_points"`
+	HasSeenResults     bool    `json:"has_seen_results"`
+	// WorkflowState can be "untaken", "pending_review",
+	// "complete", "settings_only", or "preview".
+	WorkflowState             string `json:"workflow_state"`
+	OverdueAndNeedsSubmission bool   `json:"overdue_and_needs_submission"`
+	// ValidationToken is needed to answer questions and
+	// complete the attempt. It is only sent to the
+	// user taking the quiz.
+	ValidationToken string `json:"validation_token"`
+	HTMLURL         string `json:"html_url"`
+
+	courseID int
+	client   doer
+}
+
+// QuizSubmissionQuestion is a question in a quiz submission
+// along with the current user's answer.
+type QuizSubmissionQuestion struct {
+	ID      int  `json:"id"`
+	Flagged bool `json:"flagged"`
+	// Answer is the current answer. Its type
+	// depends on the type of question.
+	Answer interface{} `json:"answer"`
+}
+
+// QuizQuestionAnswer is an answer to a quiz question. The type of
+// Answer depends on the type of question:
+//   - multiple choice and true/false: the id of the answer
+//   - multiple answers: a list of answer ids
+//   - short answer, essay, and numerical: a string
+//   - fill in multiple blanks: a map of blank ids to strings
+//   - multiple dropdowns: a map of blank ids to answer ids
+//   - matching: a list of {"answer_id": 1, "match_id": 2} objects
+//   - file upload: a list of file ids
+//
+// https://canvas.instructure.com/doc/api/quiz_submission_questions.html#Question+Answer+Formats-appendix
+type QuizQuestionAnswer struct {
+	ID     int         `json:"id"`
+	Answer interface{} `json:"answer"`
+}
+
+// QuizExtension gives a student extra time or attempts on a quiz.
+//
+// https://canvas.instructure.com/doc/api/quiz_extensions.html
+type QuizExtension struct {
+	QuizID           int       `json:"quiz_id"`
+	UserID           int       `json:"user_id"`
+	ExtraAttempts    int       `json:"extra_attempts"`
+	ExtraTime        int       `json:"extra_time"`
+	ManuallyUnlocked bool      `json:"manually_unlocked"`
+	EndAt            time.Time `json:"end_at"`
+
+	// ExtendFromNow and ExtendFromEndAt are the minutes to extend an
+	// attempt that is in progress. They are only sent to canvas.
+	ExtendFromNow   int `json:"-"`
+	ExtendFromEndAt int `json:"-"`
+}
+
+// Quiz report types.
+const (
+	StudentAnalysis = "student_analysis"
+	ItemAnalysis    = "item_analysis"
+)
+
+// QuizReport is a csv report of a quiz's results. The file
+// will not be set until the report has been generated.
+//
+// https://canvas.instructure.com/doc/api/quiz_reports.html
+type QuizReport struct {
+	ID     int `json:"id"`
+	QuizID int `json:"quiz_id"`
+	// ReportType is either StudentAnalysis or ItemAnalysis.
+	ReportType          string    `json:"report_type"`
+	ReadableType        string    `json:"readable_type"`
+	IncludesAllVersions bool      `json:"includes_all_versions"`
+	Anonymous           bool      `json:"anonymous"`
+	Generatable         bool      `json:"generatable"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+	URL                 string    `json:"url"`
+	File                *File     `json:"file"`
+	ProgressURL         string    `json:"progress_url"`
+	// Progress is the progress of generating the report.
+	Progress *Progress `json:"progress"`
+
+	courseID int
+	client   doer
+}
+
+// QuizStatistics holds the statistics of a quiz's submissions.
+//
+// https://canvas.instructure.com/doc/api/quiz_statistics.html
+type QuizStatistics struct {
+	URL                   string    `json:"url"`
+	HTMLURL               string    `json:"html_url"`
+	MultipleAttemptsExist bool      `json:"multiple_attempts_exist"`
+	GeneratedAt           time.Time `json:"generated_at"`
+	IncludesAllVersions   bool      `json:"includes_all_versions"`
+	PointsPossible        float64   `json:"points_possible"`
+	AnonymousSurvey       bool      `json:"anonymous_survey"`
+	SpeedGraderURL        string    `json:"speed_grader_url"`
+	QuizSubmissionsZipURL string    `json:"quiz_submissions_zip_url"`
+
+	QuestionStatistics   []QuizQuestionStatistics `json:"question_statistics"`
+	SubmissionStatistics struct {
+		UniqueCount           int            `json:"unique_count"`
+		ScoreAverage          float64        `json:"score_average"`
+		ScoreHigh             float64        `json:"score_high"`
+		ScoreLow              float64        `json:"score_low"`
+		ScoreStdev            float64        `json:"score_stdev"`
+		Scores                map[string]int `json:"scores"`
+		CorrectCountAverage   float64        `json:"correct_count_average"`
+		IncorrectCountAverage float64        `json:"incorrect_count_average"`
+		DurationAverage       float64        `json:"duration_average"`
+	} `json:"submission_statistics"`
+}
+
+// QuizQuestionStatistics holds the statistics of the
+// responses to one question.
+type QuizQuestionStatistics struct {
+	// ID is the question id, canvas may send it as a string.
+	ID           json.Number      `json:"id"`
+	QuestionType QuizQuestionType `json:"question_type"`
+	QuestionText string           `json:"question_text"`
+	Position     int              `json:"position"`
+	Responses    int              `json:"responses"`
+	Answers      []struct {
+		ID        json.Number `json:"id"`
+		Text      string      `json:"text"`
+		Correct   bool        `json:"correct"`
+		Responses int         `json:"responses"`
+	} `json:"answers"`
+	AnsweredStudentCount  int `json:"answered_student_count"`
+	CorrectStudentCount   int `json:"correct_student_count"`
+	IncorrectStudentCount int `json:"incorrect_student_count"`
+}
+
+// Submissions will get all the submissions for the quiz.
+//
+// https://canvas.instructure.com/doc/api/quiz_submissions.html#method.quizzes/quiz_submissions_api.index
+func (q *Quiz) Submissions(opts ...Option) ([]*QuizSubmission, error) {
+	return collectQuizSubmissions(q.SubmissionsIter(opts...))
+}
+
+// SubmissionsIter returns an iterator over the quiz's submissions.
+//
+// https://canvas.instructure.com/doc/api/quiz_submissions.html#method.quizzes/quiz_submissions_api.index
+func (q *Quiz) SubmissionsIter(opts ...Option) *QuizSubmissionIterator {
+	return &QuizSubmissionIterator{newIterator(q.client, q.path()+"/submissions", opts, func(r io.Reader, emit emitFunc) error {
+		var page quizSubmissions
+		if err := json.NewDecoder(r).Decode(&page); err != nil {
+			return err
+		}
+		for _, qs := range page.Submissions {
+			qs.setclient(q.client, q.courseID)
+			if err := emit(qs); err != nil {
+				return err
+			}
+		}
+		return nil
+	})}
+}
+
+// Submission will get one of the quiz's submissions.
+//
+// https://canvas.instructure.com/doc/api/quiz_submissions.html#method.quizzes/quiz_submissions_api.show
+func (q *Quiz) Submission(id int, opts ...Option) (*QuizSubmission, error) {
+	return q.sendSubmission(get, fmt.Sprintf("%s/submissions/%d", q.path(), id), optEnc(opts))
+}
+
+// StartSubmission will start a new attempt at the quiz for
+// the current user. Use Opt("access_code", ...) for quizzes that
+// need an access code and Opt("preview", true) to preview the quiz.
+//
+// https://canvas.instructure.com/doc/api/quiz_submissions.html#method.quizzes/quiz_submissions_api.create
+func (q *Quiz) StartSubmission(opts ...Option) (*QuizSubmission, error) {
+	return q.sendSubmission(post, q.path()+"/submissions", optEnc(opts))
+}
+
+// SetExtensions will give students extra time or attempts on the quiz.
+//
+// https://canvas.instructure.com/doc/api/quiz_extensions.html#method.quizzes/quiz_extensions.create
+func (q *Quiz) SetExtensions(exts ...QuizExtension) ([]*QuizExtension, error) {
+	type extension struct {
+		UserID           int  `json:"user_id"`
+		ExtraAttempts    int  `json:"extra_attempts,omitempty"`
+		ExtraTime        int  `json:"extra_time,omitempty"`
+		ManuallyUnlocked bool `json:"manually_unlocked,omitempty"`
+		ExtendFromNow    int  `json:"extend_from_now,omitempty"`
+		ExtendFromEndAt  int  `json:"extend_from_end_at,omitempty"`
+	}
+	body := struct {
+		Extensions []extension `json:"quiz_extensions"`
+	}{make([]extension, len(exts))}
+	for i, e := range exts {
+		body.Extensions[i] = extension{
+			UserID:           e.UserID,
+			ExtraAttempts:    e.ExtraAttempts,
+			ExtraTime:        e.ExtraTime,
+			ManuallyUnlocked: e.ManuallyUnlocked,
+			ExtendFromNow:    e.ExtendFromNow,
+			ExtendFromEndAt:  e.ExtendFromEndAt,
+		}
+	}
+	var res struct {
+		Extensions []*QuizExtension `json:"quiz_extensions"`
+	}
+	err := sendJSON(q.client, "POST", q.path()+"/extensions", nil, body, &res)
+	return res.Extensions, err
+}
+
+// Statistics will get the latest statistics of the quiz's submissions.
+//
+// https://canvas.instructure.com/doc/api/quiz_statistics.html#method.quizzes/quiz_statistics.index
+func (q *Quiz) Statistics(opts ...Option) (*QuizStatistics, error) {
+	var res struct {
+		Statistics []*QuizStatistics `json:"quiz_statistics"`
+	}
+	if err := getjson(q.client, &res, optEnc(opts), "%s/statistics", q.path()); err != nil {
+		return nil, err
+	}
+	if len(res.Statistics) == 0 {
+		return nil, errs.New("no quiz statistics returned")
+	}
+	return res.Statistics[0], nil
+}
+
+// Reports will get the quiz's reports.
+//
+// https://canvas.instructure.com/doc/api/quiz_reports.html#method.quizzes/quiz_reports.index
+func (q *Quiz) Reports(opts ...Option) ([]*QuizReport, error) {
+	reports := make([]*QuizReport, 0)
+	if err := getjson(q.client, &reports, optEnc(opts), "%s/reports", q.path()); err != nil {
+		return nil, err
+	}
+	for _, r := range reports {
+		r.setclient(q.client, q.courseID)
+	}
+	return reports, nil
+}
+
+// Report will get one of the quiz's reports.
+//
+// https://canvas.instructure.com/doc/api/quiz_reports.html#method.quizzes/quiz_reports.show
+func (q *Quiz) Report(id int) (*QuizReport, error) {
+	r := &QuizReport{ID: id, QuizID: q.ID, courseID: q.courseID, client: q.client}
+	return r, r.Refresh()
+}
+
+// CreateReport will start generating a report of the given type. Wait
+// on the report's progress and then use WriteTo to download the csv.
+//
+// https://canvas.instructure.com/doc/api/quiz_reports.html#method.quizzes/quiz_reports.create
+func (q *Quiz) CreateReport(reportType string, opts ...Option) (*QuizReport, error) {
+	p := params{
+		"quiz_report[report_type]": {reportType},
+		"include[]":                {"file", "progress"},
+	}
+	p.Add(toPrefixedOpts("quiz_report", opts))
+	r := &QuizReport{}
+	if err := send(q.client, post, q.path()+"/reports", p, r); err != nil {
+		return nil, err
+	}
+	r.setclient(q.client, q.courseID)
+	return r, nil
+}
+
+func (q *Quiz) sendSubmission(
+	method func(doer, string, encoder) (*http.Response, error),
+	path string,
+	vals encoder,
+) (*QuizSubmission, error) {
+	var res quizSubmissions
+	if err := send(q.client, method, path, vals, &res); err != nil {
+		return nil, err
+	}
+	if len(res.Submissions) == 0 {
+		return nil, fmt.Errorf("no quiz submission returned from %s", path)
+	}
+	qs := res.Submissions[0]
+	qs.setclient(q.client, q.courseID)
+	return qs, nil
+}
+
+// WithContext returns a copy of the quiz submission that uses ctx.
+func (qs *QuizSubmission) WithContext(ctx context.Context) *QuizSubmission {
+	cp := *qs
+	cp.client = withContext(qs.client, ctx)
+	return &cp
+}
+
+// Questions will get the questions in the attempt
+// along with the current answers.
+//
+// https://canvas.instructure.com/doc/api/quiz_submission_questions.html#method.quizzes/quiz_submission_questions.index
+func (qs *QuizSubmission) Questions(opts ...Option) ([]*QuizSubmissionQuestion, error) {
+	var res quizSubmissionQuestions
+	err := getjson(qs.client, &res, optEnc(opts), "/quiz_submissions/%d/questions", qs.ID)
+	return res.Questions, err
+}
+
+// Answer will save answers to the attempt's questions.
+// Use Opt("access_code", ...) for quizzes that need an access code.
+//
+// https://canvas.instructure.com/doc/api/quiz_submission_questions.html#method.quizzes/quiz_submission_questions.answer
+func (qs *QuizSubmission) Answer(answers []QuizQuestionAnswer, opts ...Option) ([]*QuizSubmissionQuestion, error) {
+	body := struct {
+		Attempt         int                  `json:"attempt"`
+		ValidationToken string               `json:"validation_token"`
+		Questions       []QuizQuestionAnswer `json:"quiz_questions"`
+	}{qs.Attempt, qs.ValidationToken, answers}
+	var res quizSubmissionQuestions
+	path := fmt.Sprintf("/quiz_submissions/%d/questions", qs.ID)
+	err := sendJSON(qs.client, "POST", path, optEnc(opts), body, &res)
+	return res.Questions, err
+}
+
+// Complete will turn in the attempt.
+//
+// https://canvas.instructure.com/doc/api/quiz_submissions.html#method.quizzes/quiz_submissions_api.complete
+func (qs *QuizSubmission) Complete(opts ...Option) error {
+	p := params{
+		"attempt":          {fmt.Sprint(qs.Attempt)},
+		"validation_token": {qs.ValidationToken},
+	}
+	p.Add(opts)
+	var res quizSubmissions
+	if err := send(qs.client, post, qs.path()+"/complete", p, &res); err != nil {
+		return err
+	}
+	if len(res.Submissions) > 0 {
+		client, courseID := qs.client, qs.courseID
+		*qs = *res.Submissions[0]
+		qs.setclient(client, courseID)
+	}
+	return nil
+}
+
+func (qs *QuizSubmission) path() string {
+	return fmt.Sprintf("/courses/%d/quizzes/%d/submissions/%d", qs.courseID, qs.QuizID, qs.ID)
+}
+
+func (qs *QuizSubmission) setclient(d doer, courseID int) {
+	qs.client = d
+	qs.courseID = courseID
+}
+
+// WithContext returns a copy of the quiz report that uses ctx.
+func (r *QuizReport) WithContext(ctx context.Context) *QuizReport {
+	cp := *r
+	cp.client = withContext(r.client, ctx)
+	return &cp
+}
+
+// Refresh will get the latest version of the report
+// along with its file and progress.
+func (r *QuizReport) Refresh() error {
+	err := getjson(
+		r.client, r, params{"include[]": {"file", "progress"}},
+		"/courses/%d/quizzes/%d/reports/%d", r.courseID, r.QuizID, r.ID,
+	)
+	if err != nil {
+		return err
+	}
+	r.setclient(r.client, r.courseID)
+	return nil
+}
+
+// WriteTo will write the report's csv file to w. The report
+// is refreshed first if it does not have a file yet.
+func (r *QuizReport) WriteTo(w io.Writer) (int64, error) {
+	if r.File == nil {
+		if err := r.Refresh(); err != nil {
+			return 0, err
+		}
+		if r.File == nil {
+			return 0, errs.New("quiz report has not been generated")
+		}
+	}
+	return r.File.WriteTo(w)
+}
+
+func (r *QuizReport) setclient(d doer, courseID int) {
+	r.client = d
+	r.courseID = courseID
+	if r.File != nil {
+		r.File.setclient(d)
+	}
+	if r.Progress != nil {
+		r.Progress.client = d
+	}
+}
+
+// quizSubmissions is how canvas wraps quiz submissions in responses.
+type quizSubmissions struct {
+	Submissions []*QuizSubmission `json:"quiz_submissions"`
+}
+
+type quizSubmissionQuestions struct {
+	Questions []*QuizSubmissionQuestion `json:"quiz_submission_questions"`
+}
+
+// QuizSubmissionIterator iterates over a paginated list of quiz submissions.
+type QuizSubmissionIterator struct{ *iterator }
+
+// Value returns the current quiz submission.
+func (it *QuizSubmissionIterator) Value() *QuizSubmission {
+	qs, _ := it.cur.(*QuizSubmission)
+	return qs
+}
+
+func collectQuizSubmissions(it *QuizSubmissionIterator) ([]*QuizSubmission, error) {
+	list := make([]*QuizSubmission, 0)
+	err := collect(it.iterator, &list)
+	return list, err
+}
@@ -0,0 +1,168 @@
+package canvas
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestQuiz_Submissions(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	quiz := &Quiz{ID: 5, courseID: 1, client: cli}
+
+	mux.HandleFunc("/api/v1/courses/1/quizzes/5/submissions", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`{"quiz_submissions":[{"id":1,"quiz_id":5,"user_id":2,"score":3.5}]}`))
+		case "POST":
+			is.Equal(r.URL.Query().Get("access_code"), "secret")
+			w.Write([]byte(`{"quiz_submissions":[{"id":9,"quiz_id":5,"attempt":1,"validation_token":"tok","workflow_state":"untaken"}]}`))
+		}
+	})
+	mux.HandleFunc("/api/v1/quiz_submissions/9/questions", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`{"quiz_submission_questions":[{"id":7,"flagged":false,"answer":null}]}`))
+		case "POST":
+			is.Equal(r.Header.Get("Content-Type"), "application/json")
+			var body struct {
+				Attempt         int    `json:"attempt"`
+				ValidationToken string `json:"validation_token"`
+				Questions       []struct {
+					ID     int         `json:"id"`
+					Answer interface{} `json:"answer"`
+				} `json:"quiz_questions"`
+			}
+			is.NoErr(json.NewDecoder(r.Body).Decode(&body))
+			is.Equal(body.Attempt, 1)
+			is.Equal(body.ValidationToken, "tok")
+			is.Equal(len(body.Questions), 2)
+			is.Equal(body.Questions[0].Answer, float64(3))
+			is.Equal(body.Questions[1].Answer, []interface{}{float64(4), float64(5)})
+			w.Write([]byte(`{"quiz_submission_questions":[{"id":7,"answer":3},{"id":8,"answer":[4,5]}]}`))
+		}
+	})
+	mux.HandleFunc("/api/v1/courses/1/quizzes/5/submissions/9/complete", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		is.Equal(q.Get("attempt"), "1")
+		is.Equal(q.Get("validation_token"), "tok")
+		w.Write([]byte(`{"quiz_submissions":[{"id":9,"quiz_id":5,"attempt":1,"workflow_state":"complete","score":2}]}`))
+	})
+
+	subs, err := quiz.Submissions()
+	is.NoErr(err)
+	is.Equal(len(subs), 1)
+	is.Equal(subs[0].Score, 3.5)
+	is.Equal(subs[0].path(), "/courses/1/quizzes/5/submissions/1")
+
+	sub, err := quiz.StartSubmission(Opt("access_code", "secret"))
+	is.NoErr(err)
+	is.Equal(sub.ValidationToken, "tok")
+
+	questions, err := sub.Questions()
+	is.NoErr(err)
+	is.Equal(len(questions), 1)
+	is.Equal(questions[0].ID, 7)
+
+	questions, err = sub.Answer([]QuizQuestionAnswer{
+		{ID: 7, Answer: 3},
+		{ID: 8, Answer: []int{4, 5}},
+	})
+	is.NoErr(err)
+	is.Equal(len(questions), 2)
+
+	is.NoErr(sub.Complete())
+	is.Equal(sub.WorkflowState, "complete")
+	is.Equal(sub.Score, 2.0)
+	is.True(sub.client != nil)
+}
+
+func TestQuiz_Extensions(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	quiz := &Quiz{ID: 5, courseID: 1, client: cli}
+
+	mux.HandleFunc("/api/v1/courses/1/quizzes/5/extensions", func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(r.Method, "POST")
+		var body map[string][]map[string]interface{}
+		is.NoErr(json.NewDecoder(r.Body).Decode(&body))
+		exts := body["quiz_extensions"]
+		is.Equal(len(exts), 2)
+		is.Equal(exts[0]["user_id"], float64(2))
+		is.Equal(exts[0]["extra_time"], float64(30))
+		_, ok := exts[0]["extra_attempts"]
+		is.True(!ok)
+		is.Equal(exts[1]["extend_from_now"], float64(10))
+		w.Write([]byte(`{"quiz_extensions":[{"quiz_id":5,"user_id":2,"extra_time":30},{"quiz_id":5,"user_id":3}]}`))
+	})
+	exts, err := quiz.SetExtensions(
+		QuizExtension{UserID: 2, ExtraTime: 30},
+		QuizExtension{UserID: 3, ExtendFromNow: 10},
+	)
+	is.NoErr(err)
+	is.Equal(len(exts), 2)
+	is.Equal(exts[0].ExtraTime, 30)
+}
+
+func TestQuiz_Statistics(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	quiz := &Quiz{ID: 5, courseID: 1, client: cli}
+
+	mux.HandleFunc("/api/v1/courses/1/quizzes/5/statistics", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"quiz_statistics":[{"points_possible":10,
+			"question_statistics":[{"id":"7","question_type":"essay_question","responses":4}],
+			"submission_statistics":{"unique_count":4,"score_average":7.5,"scores":{"50":1,"80":3}}}]}`))
+	})
+	stats, err := quiz.Statistics()
+	is.NoErr(err)
+	is.Equal(stats.PointsPossible, 10.0)
+	is.Equal(stats.QuestionStatistics[0].ID.String(), "7")
+	is.Equal(stats.QuestionStatistics[0].QuestionType, EssayQuestion)
+	is.Equal(stats.SubmissionStatistics.ScoreAverage, 7.5)
+	is.Equal(stats.SubmissionStatistics.Scores["80"], 3)
+}
+
+func TestQuiz_Reports(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	quiz := &Quiz{ID: 5, courseID: 1, client: cli}
+
+	mux.HandleFunc("/api/v1/courses/1/quizzes/5/reports", func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(r.Method, "POST")
+		q := r.URL.Query()
+		is.Equal(q.Get("quiz_report[report_type]"), StudentAnalysis)
+		is.Equal(q.Get("quiz_report[includes_all_versions]"), "true")
+		is.Equal(q["include[]"], []string{"file", "progress"})
+		w.Write([]byte(`{"id":2,"quiz_id":5,"report_type":"student_analysis","progress":{"id":11,"workflow_state":"queued"}}`))
+	})
+	mux.HandleFunc("/api/v1/courses/1/quizzes/5/reports/2", func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(r.URL.Query()["include[]"], []string{"file", "progress"})
+		w.Write([]byte(`{"id":2,"quiz_id":5,"file":{"id":3,"url":"` + server.URL + `/files/3/download"},
+			"progress":{"id":11,"workflow_state":"completed"}}`))
+	})
+	mux.HandleFunc("/files/3/download", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("name,score\nbob,10\n"))
+	})
+
+	report, err := quiz.CreateReport(StudentAnalysis, Opt("includes_all_versions", true))
+	is.NoErr(err)
+	is.Equal(report.Progress.ID, 11)
+	is.True(report.Progress.client != nil)
+	is.True(report.File == nil)
+
+	var buf bytes.Buffer
+	_, err = report.WriteTo(&buf)
+	is.NoErr(err)
+	is.Equal(buf.String(), "name,score\nbob,10\n")
+	is.True(report.Progress.Done())
+	is.True(report.File.client != nil)
+}
@@ -0,0 +1,262 @@
+package canvas
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	// DefaultMaxAttempts is the number of times a request will be sent
+	// before a throttled or failed response is given back to the caller.
+	DefaultMaxAttempts = defaultMaxAttempts
+
+	// canvas refills the rate limit bucket at roughly 10 units per second
+	rateLimitLeakRate = 10.0
+)
+
+const (
+	defaultMaxAttempts        = 5
+	defaultRateLimitThreshold = 100.0
+	defaultMinBackoff         = 500 * time.Millisecond
+	defaultMaxBackoff         = 30 * time.Second
+)
+
+// SetMaxAttempts will set the maximum number of times that the package
+// level canvas object will send a request that is being throttled or
+// failing with a server error.
+func SetMaxAttempts(n int) error { return ca.SetMaxAttempts(n) }
+
+// SetMaxAttempts will set the maximum number of times a request is sent
+// when it is being throttled or failing with a server error. Setting
+// it to one or less will turn off retries.
+func (c *Canvas) SetMaxAttempts(n int) error {
+	a, ok := transport(c.client)
+	if !ok {
+		return errNoTransport
+	}
+	r, ok := a.rt.(*retrier)
+	if !ok {
+		return errNoTransport
+	}
+	r.setMaxAttempts(n)
+	return nil
+}
+
+func newRetrier(rt http.RoundTripper) *retrier {
+	return &retrier{
+		rt:          rt,
+		maxAttempts: DefaultMaxAttempts,
+		minBackoff:  defaultMinBackoff,
+		maxBackoff:  defaultMaxBackoff,
+		threshold:   defaultRateLimitThreshold,
+	}
+}
+
+// retrier is a round tripper that keeps track of the canvas rate
+// limit headers. It will slow down requests before the rate limit
+// quota runs out and will retry requests that have been throttled or
+// have failed with a server error.
+//
+// see https://canvas.instructure.com/doc/api/file.throttling.html
+type retrier struct {
+	rt http.RoundTripper
+
+	maxAttempts int
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+	// threshold is the lowest that the rate limit quota
+	// can get before requests start being delayed.
+	threshold float64
+
+	mu        sync.Mutex
+	known     bool // false until we have seen the rate limit headers
+	remaining float64
+	max       float64
+	cost      float64
+	updated   time.Time
+}
+
+func (r *retrier) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		if err := sleep(ctx, r.reserve()); err != nil {
+			return nil, err
+		}
+		resp, err := r.rt.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		r.update(resp.Header)
+
+		if attempt >= r.attempts() || !shouldRetry(req, resp) {
+			return resp, nil
+		}
+		next, ok := rewind(req)
+		if !ok {
+			return resp, nil
+		}
+		wait := r.backoff(attempt, resp.Header)
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+		if err = sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+		req = next
+	}
+}
+
+func (r *retrier) setMaxAttempts(n int) {
+	r.mu.Lock()
+	r.maxAttempts = n
+	r.mu.Unlock()
+}
+
+func (r *retrier) attempts() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.maxAttempts
+}
+
+// reserve will take the cost of a request out of the estimated rate limit
+// quota and return the amount of time to wait before sending the request.
+func (r *retrier) reserve() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.known {
+		return 0
+	}
+	now := time.Now()
+	r.remaining += now.Sub(r.updated).Seconds() * rateLimitLeakRate
+	if r.remaining > r.max {
+		r.remaining = r.max
+	}
+	r.updated = now
+	r.remaining -= r.cost
+	if r.remaining >= r.threshold {
+		return 0
+	}
+	wait := time.Duration((r.threshold - r.remaining) / rateLimitLeakRate * float64(time.Second))
+	if wait > r.maxBackoff {
+		wait = r.maxBackoff
+	}
+	return wait
+}
+
+// update the rate limit quota using the response headers.
+func (r *retrier) update(h http.Header) {
+	remaining, err := strconv.ParseFloat(h.Get("X-Rate-Limit-Remaining"), 64)
+	if err != nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.known = true
+	r.remaining = remaining
+	r.updated = time.Now()
+	if remaining > r.max {
+		r.max = remaining
+	}
+	if cost, err := strconv.ParseFloat(h.Get("X-Request-Cost"), 64); err == nil {
+		r.cost = cost
+	}
+}
+
+// backoff returns the amount of time to wait before the next attempt.
+func (r *retrier) backoff(attempt int, h http.Header) time.Duration {
+	if secs, err := strconv.Atoi(h.Get("Retry-After")); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	d := r.minBackoff << uint(attempt-1)
+	if d > r.maxBackoff || d <= 0 {
+		d = r.maxBackoff
+	}
+	// wait somewhere between half and all of the backoff so that
+	// concurrent requests do not all retry at the same time.
+	half := int64(d / 2)
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+// shouldRetry returns true if the request should be sent again. Server
+// errors are only retried for idempotent methods because canvas may have
+// already saved a POST before failing. Throttled requests were never
+// handled so they are retried for any method.
+func shouldRetry(req *http.Request, resp *http.Response) bool {
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return isIdempotent(req.Method)
+	}
+	return isThrottled(resp)
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "", http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+var rateLimitMessage = []byte("Rate Limit Exceeded")
+
+// isThrottled returns true if the response is a
+// 403 caused by the rate limit being exceeded.
+func isThrottled(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusForbidden {
+		return false
+	}
+	remaining, err := strconv.ParseFloat(resp.Header.Get("X-Rate-Limit-Remaining"), 64)
+	if err == nil && remaining <= 0 {
+		return true
+	}
+	// canvas also sends "403 Forbidden (Rate Limit Exceeded)"
+	// as the body of throttled responses
+	b, err := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+	resp.Body = &multiReadCloser{
+		Reader: io.MultiReader(bytes.NewReader(b), resp.Body),
+		Closer: resp.Body,
+	}
+	return err == nil && bytes.Contains(b, rateLimitMessage)
+}
+
+// rewind will return a copy of the request that can be sent again. Returns
+// false if the request has a body that cannot be read a second time.
+func rewind(req *http.Request) (*http.Request, bool) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, true
+	}
+	if req.GetBody == nil {
+		return nil, false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	next := *req
+	next.Body = body
+	return &next, true
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type multiReadCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,182 @@
+package canvas
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func testRetrier(t *testing.T, c *http.Client) *retrier {
+	t.Helper()
+	a, ok := transport(c)
+	if !ok {
+		t.Fatal("could not find auth transport")
+	}
+	r, ok := a.rt.(*retrier)
+	if !ok {
+		t.Fatalf("expected a *retrier; got %T", a.rt)
+	}
+	r.minBackoff = time.Millisecond
+	r.maxBackoff = 10 * time.Millisecond
+	return r
+}
+
+func TestRetry(t *testing.T) {
+	cli, mux, server := testServer()
+	defer server.Close()
+	testRetrier(t, cli)
+	canv := &Canvas{client: cli}
+
+	var failures, attempts int
+	mux.HandleFunc("/api/v1/users/self", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		writeTestFile(t, "user.json", w)
+	})
+	failures = 2
+	if _, err := canv.CurrentUser(); err != nil {
+		t.Error(err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts; got %d", attempts)
+	}
+
+	attempts, failures = 0, 10
+	if _, err := canv.CurrentUser(); err == nil {
+		t.Error("expected an error after running out of attempts")
+	}
+	if attempts != DefaultMaxAttempts {
+		t.Errorf("expected %d attempts; got %d", DefaultMaxAttempts, attempts)
+	}
+
+	if err := canv.SetMaxAttempts(1); err != nil {
+		t.Fatal(err)
+	}
+	attempts = 0
+	if _, err := canv.CurrentUser(); err == nil {
+		t.Error("expected an error")
+	}
+	if attempts != 1 {
+		t.Errorf("should not retry when max attempts is 1; got %d attempts", attempts)
+	}
+}
+
+func TestRetry_Post(t *testing.T) {
+	cli, mux, server := testServer()
+	defer server.Close()
+	testRetrier(t, cli)
+	c := &Course{ID: 1, client: cli}
+
+	var (
+		attempts int
+		throttle bool
+	)
+	mux.HandleFunc("/api/v1/courses/1/assignment_groups", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		switch {
+		case throttle && attempts == 1:
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("403 Forbidden (Rate Limit Exceeded)\n"))
+		case throttle:
+			w.Write([]byte(`{"id":4}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+	if _, err := c.CreateAssignmentGroup(AssignmentGroup{Name: "Exams"}); err == nil {
+		t.Error("expected an error")
+	}
+	if attempts != 1 {
+		t.Errorf("a POST that fails with a server error should not be retried; got %d attempts", attempts)
+	}
+
+	attempts, throttle = 0, true
+	if _, err := c.CreateAssignmentGroup(AssignmentGroup{Name: "Exams"}); err != nil {
+		t.Error(err)
+	}
+	if attempts != 2 {
+		t.Errorf("a throttled POST should be retried; got %d attempts", attempts)
+	}
+}
+
+func TestRetry_Throttled(t *testing.T) {
+	cli, mux, server := testServer()
+	defer server.Close()
+	testRetrier(t, cli)
+	canv := &Canvas{client: cli}
+
+	var attempts int
+	mux.HandleFunc("/api/v1/users/self", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("403 Forbidden (Rate Limit Exceeded)\n"))
+			return
+		}
+		writeTestFile(t, "user.json", w)
+	})
+	mux.HandleFunc("/api/v1/users/2/settings", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusForbidden)
+	})
+	if _, err := canv.CurrentUser(); err != nil {
+		t.Error(err)
+	}
+	if attempts != 2 {
+		t.Errorf("expected throttled request to be retried once; got %d attempts", attempts)
+	}
+
+	attempts = 0
+	u := &User{ID: 2, client: cli}
+	if _, err := u.Settings(); err == nil {
+		t.Error("expected an error")
+	}
+	if attempts != 1 {
+		t.Errorf("forbidden requests that are not throttled should not be retried; got %d attempts", attempts)
+	}
+}
+
+func TestRetrier_Reserve(t *testing.T) {
+	r := newRetrier(http.DefaultTransport)
+	if d := r.reserve(); d != 0 {
+		t.Errorf("should not wait before seeing rate limit headers; got %v", d)
+	}
+	r.update(http.Header{
+		"X-Rate-Limit-Remaining": {"700.0"},
+		"X-Request-Cost":         {"20.5"},
+	})
+	if d := r.reserve(); d != 0 {
+		t.Errorf("should not wait with a full quota; got %v", d)
+	}
+	r.update(http.Header{
+		"X-Rate-Limit-Remaining": {"40"},
+		"X-Request-Cost":         {"10"},
+	})
+	d := r.reserve()
+	if d < 6*time.Second || d > 7*time.Second {
+		t.Errorf("expected to wait about 7 seconds; got %v", d)
+	}
+	if next := r.reserve(); next <= d {
+		t.Errorf("concurrent requests should wait longer: got %v then %v", d, next)
+	}
+}
+
+func TestRetrier_Backoff(t *testing.T) {
+	r := newRetrier(http.DefaultTransport)
+	for attempt := 1; attempt < 10; attempt++ {
+		d := r.backoff(attempt, http.Header{})
+		max := defaultMinBackoff << uint(attempt-1)
+		if max > defaultMaxBackoff {
+			max = defaultMaxBackoff
+		}
+		if d < max/2 || d > max {
+			t.Errorf("attempt %d: backoff of %v should be between %v and %v", attempt, d, max/2, max)
+		}
+	}
+	if d := r.backoff(1, http.Header{"Retry-After": {"3"}}); d != 3*time.Second {
+		t.Errorf("should use the Retry-After header; got %v", d)
+	}
+}
@@ -0,0 +1,538 @@
+package canvas
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/harrybrwn/errs"
+	"github.com/harrybrwn/go-querystring/query"
+)
+
+// Rubric is a set of criteria used to grade assignments.
+//
+// https://canvas.instructure.com/doc/api/rubrics.html
+type Rubric struct {
+	ID             int     `json:"id"`
+	Title          string  `json:"title"`
+	ContextID      int     `json:"context_id"`
+	ContextType    string  `json:"context_type"`
+	PointsPossible float64 `json:"points_possible"`
+	Reusable       bool    `json:"reusable"`
+	ReadOnly       bool    `json:"read_only"`
+	// FreeFormCriterionComments is true if graders can write comments
+	// instead of picking one of the ratings.
+	FreeFormCriterionComments bool             `json:"free_form_criterion_comments"`
+	HideScoreTotal            bool             `json:"hide_score_total"`
+	Criteria                  []RubricCriteria `json:"data"`
+
+	// Assessments and Associations are only set if the rubric was requested
+	// with the "assessments" or "associations" include options.
+	Assessments  []*Assessment        `json:"assessments,omitempty"`
+	Associations []*RubricAssociation `json:"associations,omitempty"`
+
+	courseID int
+	client   doer
+}
+
+// RubricCriteria has the rubric information for an assignment.
+type RubricCriteria struct {
+	Points            float64        `json:"points"`
+	ID                string         `json:"id"`
+	LearningOutcomeID string         `json:"learning_outcome_id"`
+	VendorGUID        string         `json:"vendor_guid"`
+	Description       string         `json:"description"`
+	LongDescription   string         `json:"long_description"`
+	CriterionUseRange bool           `json:"criterion_use_range"`
+	Ratings           []RubricRating `json:"ratings"`
+	IgnoreForScoring  bool           `json:"ignore_for_scoring"`
+}
+
+// RubricRating is one of the ratings that a rubric criterion can be given.
+type RubricRating struct {
+	ID              string  `json:"id"`
+	Description     string  `json:"description"`
+	LongDescription string  `json:"long_description"`
+	Points          float64 `json:"points"`
+}
+
+// RubricAssociation attaches a rubric to an assignment, course, or account.
+//
+// https://canvas.instructure.com/doc/api/rubrics.html#RubricAssociation
+type RubricAssociation struct {
+	ID       int `json:"id" url:"-"`
+	RubricID int `json:"rubric_id" url:"rubric_id,omitempty"`
+	// AssociationID is the id of the object the rubric is attached to and
+	// AssociationType is either "Assignment", "Course", or "Account".
+	AssociationID   int    `json:"association_id" url:"association_id,omitempty"`
+	AssociationType string `json:"association_type" url:"association_type,omitempty"`
+	Title           string `json:"title" url:"title,omitempty"`
+	// UseForGrading is true if the rubric's score is used as the
+	// assignment's grade.
+	UseForGrading  bool `json:"use_for_grading" url:"use_for_grading,omitempty"`
+	HideScoreTotal bool `json:"hide_score_total" url:"hide_score_total,omitempty"`
+	// Purpose is either "grading" or "bookmark".
+	Purpose string `json:"purpose" url:"purpose,omitempty"`
+
+	courseID int
+	client   doer
+}
+
+type rubricAssociationOptions struct {
+	Association RubricAssociation `url:"rubric_association"`
+}
+
+// Assessment is a rubric assessment of a submission.
+//
+// https://canvas.instructure.com/doc/api/rubrics.html#RubricAssessment
+type Assessment struct {
+	ID                  int     `json:"id"`
+	RubricID            int     `json:"rubric_id"`
+	RubricAssociationID int     `json:"rubric_association_id"`
+	Score               float64 `json:"score"`
+	ArtifactType        string  `json:"artifact_type"`
+	ArtifactID          int     `json:"artifact_id"`
+	ArtifactAttempt     int     `json:"artifact_attempt"`
+	// AssessmentType is "grading", "peer_review", or "provisional_grade".
+	AssessmentType string `json:"assessment_type"`
+	AssessorID     int    `json:"assessor_id"`
+	Data           []struct {
+		CriterionID string  `json:"criterion_id"`
+		Points      float64 `json:"points"`
+		RatingID    string  `json:"id"`
+		Comments    string  `json:"comments"`
+	} `json:"data"`
+
+	courseID int
+	client   doer
+}
+
+// Rubrics returns a channel of the course's rubrics.
+//
+// https://canvas.instructure.com/doc/api/rubrics.html#method.rubrics_api.index
+func (c *Course) Rubrics(opts ...Option) <-chan *Rubric {
+	it := c.RubricsIter(opts...)
+	it.handler = c.errorHandler
+	ch := make(chan *Rubric)
+	stream(it.iterator, ch)
+	return ch
+}
+
+// RubricsIter returns an iterator over the course's rubrics.
+//
+// https://canvas.instructure.com/doc/api/rubrics.html#method.rubrics_api.index
+func (c *Course) RubricsIter(opts ...Option) *RubricIterator {
+	return &RubricIterator{newIterator(c.client, c.id("/courses/%d/rubrics"), opts, func(r io.Reader, emit emitFunc) error {
+		list := make([]*Rubric, 0, defaultPerPage)
+		if err := json.NewDecoder(r).Decode(&list); err != nil {
+			return err
+		}
+		for _, rb := range list {
+			rb.setclient(c.client, c.ID)
+			if err := emit(rb); err != nil {
+				return err
+			}
+		}
+		return nil
+	})}
+}
+
+// ListRubrics returns a slice of the course's rubrics.
+//
+// https://canvas.instructure.com/doc/api/rubrics.html#method.rubrics_api.index
+func (c *Course) ListRubrics(opts ...Option) ([]*Rubric, error) {
+	it := c.RubricsIter(opts...)
+	list := make([]*Rubric, 0)
+	err := collect(it.iterator, &list)
+	return list, err
+}
+
+// Rubric will get one of the course's rubrics. Use
+// IncludeOpt("assessments", "associations") to get the
+// rubric's assessments and associations.
+//
+// https://canvas.instructure.com/doc/api/rubrics.html#method.rubrics_api.show
+func (c *Course) Rubric(id int, opts ...Option) (*Rubric, error) {
+	r := &Rubric{}
+	if err := getjson(c.client, r, optEnc(opts), "/courses/%d/rubrics/%d", c.ID, id); err != nil {
+		return nil, err
+	}
+	r.setclient(c.client, c.ID)
+	return r, nil
+}
+
+// CreateRubric will create a new rubric in the course. The rubric is
+// bookmarked in the course unless options are given to associate it
+// with something else (ex. Opt("rubric_association[association_type]", "Assignment")).
+// Rubrics read using ReadRubricCSV or ReadRubricJSON can be imported
+// into a course with CreateRubric.
+//
+// https://canvas.instructure.com/doc/api/rubrics.html#method.rubrics.create
+func (c *Course) CreateRubric(r Rubric, opts ...Option) (*Rubric, error) {
+	q := r.params()
+	q["rubric_association[association_id]"] = []string{strconv.Itoa(c.ID)}
+	q["rubric_association[association_type]"] = []string{"Course"}
+	q["rubric_association[purpose]"] = []string{"bookmark"}
+	q.Add(opts)
+	rubric := &Rubric{courseID: c.ID, client: c.client}
+	if err := rubric.send(post, c.id("/courses/%d/rubrics"), q); err != nil {
+		return nil, err
+	}
+	return rubric, nil
+}
+
+// AssociateRubric will attach a rubric to an assignment, the course, or
+// an account.
+//
+// https://canvas.instructure.com/doc/api/rubrics.html#method.rubric_associations.create
+func (c *Course) AssociateRubric(a RubricAssociation) (*RubricAssociation, error) {
+	a.courseID, a.client = c.ID, c.client
+	if err := a.send(post, c.id("/courses/%d/rubric_associations")); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// WithContext returns a copy of the rubric that uses ctx.
+func (r *Rubric) WithContext(ctx context.Context) *Rubric {
+	cp := *r
+	cp.client = withContext(r.client, ctx)
+	return &cp
+}
+
+// Update will send the rubric's title and criteria to canvas
+// and replace them with the updated rubric.
+//
+// https://canvas.instructure.com/doc/api/rubrics.html#method.rubrics.update
+func (r *Rubric) Update(opts ...Option) error {
+	q := r.params()
+	q.Add(opts)
+	return r.send(put, r.path(), q)
+}
+
+// Delete will delete the rubric.
+//
+// https://canvas.instructure.com/doc/api/rubrics.html#method.rubrics.destroy
+func (r *Rubric) Delete() error {
+	resp, err := delete(r.client, r.path(), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// WriteJSON will export the rubric as json.
+func (r *Rubric) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// ReadRubricJSON will read a rubric that was exported using WriteJSON.
+func ReadRubricJSON(r io.Reader) (*Rubric, error) {
+	rubric := &Rubric{}
+	return rubric, json.NewDecoder(r).Decode(rubric)
+}
+
+var rubricCSVHeader = []string{
+	"Rubric Name", "Criteria Name", "Criteria Description", "Criteria Enable Range",
+}
+
+var ratingCSVHeader = []string{"Rating Name", "Rating Description", "Rating Points"}
+
+// WriteCSV will export the rubric as a csv with one criterion per row
+// followed by each of the criterion's ratings. This is the same
+// format that canvas uses for rubric imports.
+func (r *Rubric) WriteCSV(w io.Writer) error {
+	ratings := 0
+	for _, c := range r.Criteria {
+		if len(c.Ratings) > ratings {
+			ratings = len(c.Ratings)
+		}
+	}
+	header := append([]string{}, rubricCSVHeader...)
+	for i := 0; i < ratings; i++ {
+		header = append(header, ratingCSVHeader...)
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, c := range r.Criteria {
+		row := []string{r.Title, c.Description, c.LongDescription, strconv.FormatBool(c.CriterionUseRange)}
+		for _, rating := range c.Ratings {
+			row = append(row,
+				rating.Description,
+				rating.LongDescription,
+				strconv.FormatFloat(rating.Points, 'f', -1, 64),
+			)
+		}
+		for len(row) < len(header) {
+			row = append(row, "")
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadRubricCSV will read rubrics from a csv in the format written by
+// WriteCSV. Rows are grouped into rubrics by the rubric name.
+func ReadRubricCSV(r io.Reader) ([]*Rubric, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, errs.New("empty rubric csv")
+	}
+	var (
+		rubrics = make([]*Rubric, 0)
+		byName  = make(map[string]*Rubric)
+		n       = len(rubricCSVHeader)
+	)
+	for i, row := range rows[1:] {
+		if len(row) < n || (len(row)-n)%len(ratingCSVHeader) != 0 {
+			return nil, fmt.Errorf("rubric csv line %d: wrong number of fields", i+2)
+		}
+		rubric, ok := byName[row[0]]
+		if !ok {
+			rubric = &Rubric{Title: row[0]}
+			byName[row[0]] = rubric
+			rubrics = append(rubrics, rubric)
+		}
+		c := RubricCriteria{Description: row[1], LongDescription: row[2]}
+		if row[3] != "" {
+			if c.CriterionUseRange, err = strconv.ParseBool(row[3]); err != nil {
+				return nil, fmt.Errorf("rubric csv line %d: %w", i+2, err)
+			}
+		}
+		for j := n; j < len(row); j += len(ratingCSVHeader) {
+			if row[j] == "" && row[j+1] == "" && row[j+2] == "" {
+				continue
+			}
+			rating := RubricRating{Description: row[j], LongDescription: row[j+1]}
+			if rating.Points, err = strconv.ParseFloat(row[j+2], 64); err != nil {
+				return nil, fmt.Errorf("rubric csv line %d: %w", i+2, err)
+			}
+			if rating.Points > c.Points {
+				c.Points = rating.Points
+			}
+			c.Ratings = append(c.Ratings, rating)
+		}
+		rubric.Criteria = append(rubric.Criteria, c)
+		rubric.PointsPossible += c.Points
+	}
+	return rubrics, nil
+}
+
+// params encodes the rubric's title and criteria. The criteria are
+// indexed (ex. rubric[criteria][0][ratings][1][points]) so that
+// each rating stays with its criterion.
+func (r *Rubric) params() params {
+	q := params{}
+	if r.Title != "" {
+		q.Set("rubric[title]", r.Title)
+	}
+	if r.FreeFormCriterionComments {
+		q.Set("rubric[free_form_criterion_comments]", "true")
+	}
+	for i, c := range r.Criteria {
+		prefix := fmt.Sprintf("rubric[criteria][%d]", i)
+		if c.ID != "" {
+			q.Set(prefix+"[id]", c.ID)
+		}
+		q.Set(prefix+"[description]", c.Description)
+		q.Set(prefix+"[long_description]", c.LongDescription)
+		q.Set(prefix+"[points]", strconv.FormatFloat(c.Points, 'f', -1, 64))
+		if c.CriterionUseRange {
+			q.Set(prefix+"[criterion_use_range]", "true")
+		}
+		for j, rating := range c.Ratings {
+			key := fmt.Sprintf("%s[ratings][%d]", prefix, j)
+			if rating.ID != "" {
+				q.Set(key+"[id]", rating.ID)
+			}
+			q.Set(key+"[description]", rating.Description)
+			q.Set(key+"[long_description]", rating.LongDescription)
+			q.Set(key+"[points]", strconv.FormatFloat(rating.Points, 'f', -1, 64))
+		}
+	}
+	return q
+}
+
+func (r *Rubric) path() string {
+	return fmt.Sprintf("/courses/%d/rubrics/%d", r.courseID, r.ID)
+}
+
+// send will make a request and replace the rubric with the one
+// in the response. Canvas sends back the rubric along with its
+// association when rubrics are created or updated.
+func (r *Rubric) send(
+	method func(doer, string, encoder) (*http.Response, error),
+	path string,
+	q encoder,
+) error {
+	var res struct {
+		Rubric *Rubric `json:"rubric"`
+	}
+	res.Rubric = r
+	if err := send(r.client, method, path, q, &res); err != nil {
+		return err
+	}
+	r.setclient(r.client, r.courseID)
+	return nil
+}
+
+func (r *Rubric) setclient(d doer, courseID int) {
+	r.client = d
+	r.courseID = courseID
+	for _, a := range r.Assessments {
+		a.client, a.courseID = d, courseID
+	}
+	for _, a := range r.Associations {
+		a.client, a.courseID = d, courseID
+	}
+}
+
+// WithContext returns a copy of the association that uses ctx.
+func (ra *RubricAssociation) WithContext(ctx context.Context) *RubricAssociation {
+	cp := *ra
+	cp.client = withContext(ra.client, ctx)
+	return &cp
+}
+
+// Update will send the association's fields to canvas
+// and replace them with the updated association.
+//
+// https://canvas.instructure.com/doc/api/rubrics.html#method.rubric_associations.update
+func (ra *RubricAssociation) Update() error {
+	return ra.send(put, ra.path())
+}
+
+// Delete will delete the association. The rubric is not deleted.
+//
+// https://canvas.instructure.com/doc/api/rubrics.html#method.rubric_associations.destroy
+func (ra *RubricAssociation) Delete() error {
+	resp, err := delete(ra.client, ra.path(), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Assess will assess a user's submission using the rubric. Use
+// Opt("rubric_assessment[assessment_type]", "peer_review") for
+// peer reviews.
+//
+// https://canvas.instructure.com/doc/api/rubrics.html#method.rubric_assessments.create
+func (ra *RubricAssociation) Assess(userID int, assessment RubricAssessment, opts ...Option) (*Assessment, error) {
+	q := params{
+		"rubric_assessment[user_id]":         {strconv.Itoa(userID)},
+		"rubric_assessment[assessment_type]": {"grading"},
+	}
+	assessment.addTo(q, "rubric_assessment")
+	q.Add(opts)
+	a := &Assessment{}
+	if err := send(ra.client, post, ra.path()+"/rubric_assessments", q, a); err != nil {
+		return nil, err
+	}
+	a.client, a.courseID = ra.client, ra.courseID
+	return a, nil
+}
+
+func (ra *RubricAssociation) path() string {
+	return fmt.Sprintf("/courses/%d/rubric_associations/%d", ra.courseID, ra.ID)
+}
+
+// send will send the association's fields and replace them with the
+// association in the response. Canvas sometimes wraps the association
+// with its rubric.
+func (ra *RubricAssociation) send(
+	method func(doer, string, encoder) (*http.Response, error),
+	path string,
+) error {
+	q, err := query.Values(&rubricAssociationOptions{*ra})
+	if err != nil {
+		return err
+	}
+	var raw json.RawMessage
+	if err = send(ra.client, method, path, q, &raw); err != nil {
+		return err
+	}
+	var res struct {
+		Association json.RawMessage `json:"rubric_association"`
+	}
+	if err = json.Unmarshal(raw, &res); err != nil {
+		return err
+	}
+	if res.Association != nil {
+		raw = res.Association
+	}
+	return json.Unmarshal(raw, ra)
+}
+
+// WithContext returns a copy of the assessment that uses ctx.
+func (a *Assessment) WithContext(ctx context.Context) *Assessment {
+	cp := *a
+	cp.client = withContext(a.client, ctx)
+	return &cp
+}
+
+// Criteria returns the assessment of each criterion.
+func (a *Assessment) Criteria() RubricAssessment {
+	ra := make(RubricAssessment, len(a.Data))
+	for _, d := range a.Data {
+		ra[d.CriterionID] = CriterionAssessment{
+			Points:   d.Points,
+			RatingID: d.RatingID,
+			Comments: d.Comments,
+		}
+	}
+	return ra
+}
+
+// Update will replace the assessment's criteria.
+//
+// https://canvas.instructure.com/doc/api/rubrics.html#method.rubric_assessments.update
+func (a *Assessment) Update(assessment RubricAssessment, opts ...Option) error {
+	q := params{}
+	assessment.addTo(q, "rubric_assessment")
+	q.Add(opts)
+	return send(a.client, put, a.path(), q, a)
+}
+
+// Delete will delete the assessment.
+//
+// https://canvas.instructure.com/doc/api/rubrics.html#method.rubric_assessments.destroy
+func (a *Assessment) Delete() error {
+	resp, err := delete(a.client, a.path(), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (a *Assessment) path() string {
+	return fmt.Sprintf(
+		"/courses/%d/rubric_associations/%d/rubric_assessments/%d",
+		a.courseID, a.RubricAssociationID, a.ID,
+	)
+}
+
+// RubricIterator iterates over a paginated list of rubrics.
+type RubricIterator struct{ *iterator }
+
+// Value returns the current rubric.
+func (it *RubricIterator) Value() *Rubric {
+	r, _ := it.cur.(*Rubric)
+	return r
+}
@@ -0,0 +1,177 @@
+package canvas
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestCourse_Rubrics(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	c := &Course{ID: 1, client: cli}
+
+	mux.HandleFunc("/api/v1/courses/1/rubrics", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`[{"id":2,"title":"Essay","points_possible":10,"data":[
+				{"id":"_1","description":"Grammar","points":10,"ratings":[
+					{"id":"r1","description":"Good","points":10},{"id":"r2","description":"Bad","points":0}]}]}]`))
+		case "POST":
+			is.Equal(q.Get("rubric[title]"), "Lab")
+			is.Equal(q.Get("rubric[criteria][0][description]"), "Method")
+			is.Equal(q.Get("rubric[criteria][0][ratings][1][points]"), "2.5")
+			is.Equal(q.Get("rubric[criteria][1][points]"), "3")
+			is.Equal(q.Get("rubric_association[association_id]"), "7")
+			is.Equal(q.Get("rubric_association[association_type]"), "Assignment")
+			is.Equal(q.Get("rubric_association[purpose]"), "bookmark")
+			w.Write([]byte(`{"rubric":{"id":3,"title":"Lab","points_possible":8},
+				"rubric_association":{"id":4,"rubric_id":3}}`))
+		}
+	})
+	var method string
+	mux.HandleFunc("/api/v1/courses/1/rubrics/3", func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		if r.Method == "PUT" {
+			is.Equal(r.URL.Query().Get("rubric[title]"), "Lab Report")
+		}
+		w.Write([]byte(`{"rubric":{"id":3,"title":"Lab Report","points_possible":8}}`))
+	})
+
+	rubrics, err := c.ListRubrics()
+	is.NoErr(err)
+	is.Equal(len(rubrics), 1)
+	is.Equal(rubrics[0].Criteria[0].Ratings[1].Description, "Bad")
+	is.True(rubrics[0].client != nil)
+
+	r, err := c.CreateRubric(Rubric{
+		Title: "Lab",
+		Criteria: []RubricCriteria{
+			{Description: "Method", Points: 5, Ratings: []RubricRating{
+				{Description: "Full", Points: 5}, {Description: "Half", Points: 2.5},
+			}},
+			{Description: "Results", Points: 3},
+		},
+	}, Opt("rubric_association[association_id]", 7), Opt("rubric_association[association_type]", "Assignment"))
+	is.NoErr(err)
+	is.Equal(r.ID, 3)
+	is.Equal(r.path(), "/courses/1/rubrics/3")
+
+	r.Title = "Lab Report"
+	is.NoErr(r.Update())
+	is.Equal(method, "PUT")
+	is.Equal(r.Title, "Lab Report")
+	is.NoErr(r.Delete())
+	is.Equal(method, "DELETE")
+}
+
+func TestRubricAssociation_Assess(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	c := &Course{ID: 1, client: cli}
+
+	mux.HandleFunc("/api/v1/courses/1/rubric_associations", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		is.Equal(q.Get("rubric_association[rubric_id]"), "3")
+		is.Equal(q.Get("rubric_association[association_id]"), "7")
+		is.Equal(q.Get("rubric_association[association_type]"), "Assignment")
+		is.Equal(q.Get("rubric_association[use_for_grading]"), "true")
+		w.Write([]byte(`{"rubric":{"id":3},"rubric_association":{"id":4,"rubric_id":3,"association_id":7,"association_type":"Assignment","use_for_grading":true}}`))
+	})
+	mux.HandleFunc("/api/v1/courses/1/rubric_associations/4/rubric_assessments", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		is.Equal(q.Get("rubric_assessment[user_id]"), "9")
+		is.Equal(q.Get("rubric_assessment[assessment_type]"), "grading")
+		is.Equal(q.Get("rubric_assessment[_1][points]"), "4")
+		is.Equal(q.Get("rubric_assessment[_1][comments]"), "nice")
+		w.Write([]byte(`{"id":5,"rubric_id":3,"rubric_association_id":4,"score":4,
+			"data":[{"criterion_id":"_1","points":4,"comments":"nice"}]}`))
+	})
+	var method string
+	mux.HandleFunc("/api/v1/courses/1/rubric_associations/4/rubric_assessments/5", func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		if r.Method == "PUT" {
+			is.Equal(r.URL.Query().Get("rubric_assessment[_1][points]"), "5")
+		}
+		w.Write([]byte(`{"id":5,"rubric_id":3,"rubric_association_id":4,"score":5,"data":[{"criterion_id":"_1","points":5}]}`))
+	})
+
+	ra, err := c.AssociateRubric(RubricAssociation{
+		RubricID:        3,
+		AssociationID:   7,
+		AssociationType: "Assignment",
+		UseForGrading:   true,
+	})
+	is.NoErr(err)
+	is.Equal(ra.ID, 4)
+	is.True(ra.UseForGrading)
+
+	a, err := ra.Assess(9, RubricAssessment{"_1": {Points: 4, Comments: "nice"}})
+	is.NoErr(err)
+	is.Equal(a.Score, 4.0)
+	is.Equal(a.Criteria()["_1"].Comments, "nice")
+
+	is.NoErr(a.Update(RubricAssessment{"_1": {Points: 5}}))
+	is.Equal(method, "PUT")
+	is.Equal(a.Score, 5.0)
+	is.NoErr(a.Delete())
+	is.Equal(method, "DELETE")
+}
+
+func TestRubric_CSV(t *testing.T) {
+	is := is.New(t)
+	r := &Rubric{
+		Title: "Essay",
+		Criteria: []RubricCriteria{
+			{Description: "Grammar", LongDescription: "spelling, punctuation", Ratings: []RubricRating{
+				{Description: "Good", Points: 5}, {Description: "Ok", Points: 2.5}, {Description: "Bad", Points: 0},
+			}},
+			{Description: "Thesis", CriterionUseRange: true, Ratings: []RubricRating{
+				{Description: "Clear", LongDescription: "one sentence", Points: 3},
+			}},
+		},
+	}
+	var buf bytes.Buffer
+	is.NoErr(r.WriteCSV(&buf))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	is.Equal(len(lines), 3)
+	is.True(strings.HasPrefix(lines[0], "Rubric Name,Criteria Name,Criteria Description,Criteria Enable Range,Rating Name"))
+	is.Equal(lines[2], "Essay,Thesis,,true,Clear,one sentence,3,,,,,,")
+
+	rubrics, err := ReadRubricCSV(&buf)
+	is.NoErr(err)
+	is.Equal(len(rubrics), 1)
+	is.Equal(rubrics[0].Title, "Essay")
+	is.Equal(rubrics[0].PointsPossible, 8.0)
+	is.Equal(len(rubrics[0].Criteria), 2)
+	is.Equal(rubrics[0].Criteria[0].Points, 5.0)
+	is.Equal(rubrics[0].Criteria[0].Ratings, r.Criteria[0].Ratings)
+	is.Equal(rubrics[0].Criteria[1].Ratings, r.Criteria[1].Ratings)
+	is.True(rubrics[0].Criteria[1].CriterionUseRange)
+
+	_, err = ReadRubricCSV(strings.NewReader("Rubric Name\nEssay,Grammar\n"))
+	is.True(err != nil)
+}
+
+func TestRubric_JSON(t *testing.T) {
+	is := is.New(t)
+	r := &Rubric{
+		ID:    2,
+		Title: "Essay",
+		Criteria: []RubricCriteria{
+			{ID: "_1", Description: "Grammar", Points: 5, Ratings: []RubricRating{{ID: "r1", Description: "Good", Points: 5}}},
+		},
+	}
+	var buf bytes.Buffer
+	is.NoErr(r.WriteJSON(&buf))
+	res, err := ReadRubricJSON(&buf)
+	is.NoErr(err)
+	is.Equal(res.Title, "Essay")
+	is.Equal(res.Criteria, r.Criteria)
+}
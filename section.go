@@ -0,0 +1,250 @@
+package canvas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/harrybrwn/go-querystring/query"
+)
+
+// Section is a course section.
+//
+// https://canvas.instructure.com/doc/api/sections.html
+type Section struct {
+	ID                                int       `json:"id" url:"-"`
+	Name                              string    `json:"name" url:"name,omitempty"`
+	SisSectionID                      string    `json:"sis_section_id" url:"sis_section_id,omitempty"`
+	IntegrationID                     string    `json:"integration_id" url:"integration_id,omitempty"`
+	SisImportID                       int       `json:"sis_import_id" url:"-"`
+	CourseID                          int       `json:"course_id" url:"-"`
+	SisCourseID                       string    `json:"sis_course_id" url:"-"`
+	StartAt                           time.Time `json:"start_at" url:"start_at,omitempty"`
+	EndAt                             time.Time `json:"end_at" url:"end_at,omitempty"`
+	RestrictEnrollmentsToSectionDates bool      `json:"restrict_enrollments_to_section_dates" url:"restrict_enrollments_to_section_dates,omitempty"`
+	// NonxlistCourseID is the id of the section's original
+	// course if the section has been crosslisted.
+	NonxlistCourseID int     `json:"nonxlist_course_id" url:"-"`
+	TotalStudents    int     `json:"total_students" url:"-"`
+	Students         []*User `json:"students" url:"-"`
+
+	client       doer
+	errorHandler errorHandlerFunc
+}
+
+type sectionOptions struct {
+	Section `url:"course_section"`
+}
+
+// WithContext returns a copy of the section that uses ctx.
+func (s *Section) WithContext(ctx context.Context) *Section {
+	cp := *s
+	cp.client = withContext(s.client, ctx)
+	return &cp
+}
+
+// Sections returns a channel of the course's sections.
+//
+// https://canvas.instructure.com/doc/api/sections.html#method.sections.index
+func (c *Course) Sections(opts ...Option) <-chan *Section {
+	it := c.SectionsIter(opts...)
+	it.handler = c.errorHandler
+	ch := make(chan *Section)
+	stream(it.iterator, ch)
+	return ch
+}
+
+// SectionsIter returns an iterator over the course's sections.
+//
+// https://canvas.instructure.com/doc/api/sections.html#method.sections.index
+func (c *Course) SectionsIter(opts ...Option) *SectionIterator {
+	return &SectionIterator{newIterator(c.client, c.id("/courses/%d/sections"), opts, func(r io.Reader, emit emitFunc) error {
+		list := make([]*Section, 0, defaultPerPage)
+		if err := json.NewDecoder(r).Decode(&list); err != nil {
+			return err
+		}
+		for _, s := range list {
+			s.setclient(c.client)
+			s.errorHandler = c.errorHandler
+			if err := emit(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	})}
+}
+
+// ListSections returns a slice of the course's sections.
+//
+// https://canvas.instructure.com/doc/api/sections.html#method.sections.index
+func (c *Course) ListSections(opts ...Option) ([]*Section, error) {
+	it := c.SectionsIter(opts...)
+	sections := make([]*Section, 0)
+	err := collect(it.iterator, &sections)
+	return sections, err
+}
+
+// Section will get a section from the course given a section id.
+//
+// https://canvas.instructure.com/doc/api/sections.html#method.sections.show
+func (c *Course) Section(id int, opts ...Option) (*Section, error) {
+	s := &Section{errorHandler: c.errorHandler}
+	if err := getjson(c.client, s, optEnc(opts), "/courses/%d/sections/%d", c.ID, id); err != nil {
+		return nil, err
+	}
+	s.setclient(c.client)
+	return s, nil
+}
+
+// CreateSection will create a new section in the course.
+//
+// https://canvas.instructure.com/doc/api/sections.html#method.sections.create
+func (c *Course) CreateSection(s Section, opts ...Option) (*Section, error) {
+	q, err := query.Values(&sectionOptions{s})
+	if err != nil {
+		return nil, err
+	}
+	params(q).Add(opts)
+	resp, err := post(c.client, c.id("/courses/%d/sections"), q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	sec := &Section{errorHandler: c.errorHandler}
+	if err = json.NewDecoder(resp.Body).Decode(sec); err != nil {
+		return nil, err
+	}
+	sec.setclient(c.client)
+	return sec, nil
+}
+
+// Update will send the section's fields to canvas
+// and replace them with the updated section.
+//
+// https://canvas.instructure.com/doc/api/sections.html#method.sections.update
+func (s *Section) Update(opts ...Option) error {
+	q, err := query.Values(&sectionOptions{*s})
+	if err != nil {
+		return err
+	}
+	params(q).Add(opts)
+	return s.send(put, fmt.Sprintf("/sections/%d", s.ID), q)
+}
+
+// Delete will delete the section.
+//
+// https://canvas.instructure.com/doc/api/sections.html#method.sections.destroy
+func (s *Section) Delete() error {
+	return s.send(delete, fmt.Sprintf("/sections/%d", s.ID), nil)
+}
+
+// Crosslist will move the section into another course.
+//
+// https://canvas.instructure.com/doc/api/sections.html#method.sections.crosslist
+func (s *Section) Crosslist(courseID int, opts ...Option) error {
+	return s.send(post, fmt.Sprintf("/sections/%d/crosslist/%d", s.ID, courseID), optEnc(opts))
+}
+
+// Decrosslist will move a crosslisted section back into its original course.
+//
+// https://canvas.instructure.com/doc/api/sections.html#method.sections.uncrosslist
+func (s *Section) Decrosslist(opts ...Option) error {
+	return s.send(delete, fmt.Sprintf("/sections/%d/crosslist", s.ID), optEnc(opts))
+}
+
+func (s *Section) send(
+	method func(doer, string, encoder) (*http.Response, error),
+	path string,
+	q encoder,
+) error {
+	if err := send(s.client, method, path, q, s); err != nil {
+		return err
+	}
+	s.setclient(s.client)
+	return nil
+}
+
+// Enrollments returns a channel of the section's enrollments.
+//
+// https://canvas.instructure.com/doc/api/enrollments.html#method.enrollments_api.index
+func (s *Section) Enrollments(opts ...Option) <-chan *Enrollment {
+	return enrollmentsChannel(s.EnrollmentsIter(opts...), s.handler())
+}
+
+// EnrollmentsIter returns an iterator over the section's enrollments.
+//
+// https://canvas.instructure.com/doc/api/enrollments.html#method.enrollments_api.index
+func (s *Section) EnrollmentsIter(opts ...Option) *EnrollmentIterator {
+	return newEnrollmentIterator(s.client, fmt.Sprintf("/sections/%d/enrollments", s.ID), opts)
+}
+
+// ListEnrollments returns a slice of the section's enrollments.
+//
+// https://canvas.instructure.com/doc/api/enrollments.html#method.enrollments_api.index
+func (s *Section) ListEnrollments(opts ...Option) ([]*Enrollment, error) {
+	return collectEnrollments(s.EnrollmentsIter(opts...))
+}
+
+// Users returns a channel of the users enrolled in the section.
+func (s *Section) Users(opts ...Option) <-chan *User {
+	it := s.UsersIter(opts...)
+	it.handler = s.handler()
+	ch := make(chan *User)
+	stream(it.iterator, ch)
+	return ch
+}
+
+// UsersIter returns an iterator over the users enrolled in the section.
+// Canvas has no endpoint for a section's users so this uses the section's
+// enrollments and will give one user for every enrollment.
+func (s *Section) UsersIter(opts ...Option) *UserIterator {
+	opts = append(enrollmentFilters(opts), IncludeOpt("user"))
+	path := fmt.Sprintf("/sections/%d/enrollments", s.ID)
+	return &UserIterator{newIterator(s.client, path, opts, func(r io.Reader, emit emitFunc) error {
+		list := make([]*Enrollment, 0, defaultPerPage)
+		if err := json.NewDecoder(r).Decode(&list); err != nil {
+			return err
+		}
+		for _, e := range list {
+			if e.User == nil {
+				continue
+			}
+			e.User.client = s.client
+			if err := emit(e.User); err != nil {
+				return err
+			}
+		}
+		return nil
+	})}
+}
+
+// ListUsers returns a slice of the users enrolled in the section.
+func (s *Section) ListUsers(opts ...Option) ([]*User, error) {
+	return collectUsers(s.UsersIter(opts...))
+}
+
+func (s *Section) handler() errorHandlerFunc {
+	if s.errorHandler != nil {
+		return s.errorHandler
+	}
+	return ConcurrentErrorHandler
+}
+
+func (s *Section) setclient(d doer) {
+	s.client = d
+	for _, u := range s.Students {
+		u.client = d
+	}
+}
+
+// SectionIterator iterates over a paginated list of sections.
+type SectionIterator struct{ *iterator }
+
+// Value returns the current section.
+func (it *SectionIterator) Value() *Section {
+	s, _ := it.cur.(*Section)
+	return s
+}
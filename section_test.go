@@ -0,0 +1,121 @@
+package canvas
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestCourse_Sections(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	c := &Course{ID: 1, client: cli, errorHandler: func(e error) error {
+		t.Error(e)
+		return e
+	}}
+
+	mux.HandleFunc("/api/v1/courses/1/sections", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			handlePagingatedList(t, 3, "section.json")(w, r)
+		case "POST":
+			is.Equal(r.URL.Query().Get("course_section[name]"), "Section B")
+			is.Equal(r.URL.Query().Get("course_section[sis_section_id]"), "")
+			writeTestFile(t, "section.json", w)
+		default:
+			t.Errorf("wrong method %s", r.Method)
+		}
+	})
+	mux.HandleFunc("/api/v1/courses/1/sections/4", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "GET")
+		writeTestFile(t, "section.json", w)
+	})
+
+	sections, err := c.ListSections()
+	is.NoErr(err)
+	is.Equal(len(sections), 3)
+	n := 0
+	for s := range c.Sections() {
+		is.Equal(s.ID, 4)
+		is.True(s.client != nil)
+		n++
+	}
+	is.Equal(n, 3)
+
+	s, err := c.Section(4)
+	is.NoErr(err)
+	is.Equal(s.Name, "Section A")
+	is.Equal(s.TotalStudents, 13)
+	is.True(s.EndAt.IsZero())
+
+	s, err = c.CreateSection(Section{Name: "Section B"})
+	is.NoErr(err)
+	is.Equal(s.ID, 4)
+}
+
+func TestSection(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	s := &Section{ID: 4, Name: "renamed", client: cli}
+
+	var method, path string
+	var query map[string][]string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		method, path, query = r.Method, r.URL.Path, r.URL.Query()
+		writeTestFile(t, "section.json", w)
+	}
+	mux.HandleFunc("/api/v1/sections/4", handler)
+	mux.HandleFunc("/api/v1/sections/4/crosslist", handler)
+	mux.HandleFunc("/api/v1/sections/4/crosslist/9", handler)
+
+	is.NoErr(s.Update())
+	is.Equal(method, "PUT")
+	is.Equal(query["course_section[name]"], []string{"renamed"})
+	is.Equal(s.Name, "Section A")
+
+	is.NoErr(s.Crosslist(9))
+	is.Equal(method, "POST")
+	is.Equal(path, "/api/v1/sections/4/crosslist/9")
+
+	is.NoErr(s.Decrosslist())
+	is.Equal(method, "DELETE")
+	is.Equal(path, "/api/v1/sections/4/crosslist")
+
+	is.NoErr(s.Delete())
+	is.Equal(method, "DELETE")
+	is.Equal(path, "/api/v1/sections/4")
+}
+
+func TestSection_Users(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	s := &Section{ID: 4, client: cli}
+
+	var query map[string][]string
+	mux.HandleFunc("/api/v1/sections/4/enrollments", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "GET")
+		query = r.URL.Query()
+		w.Write([]byte(`[
+			{"id":1,"course_section_id":4,"user_id":2,"type":"StudentEnrollment","user":{"id":2,"name":"Sheldon Cooper"}},
+			{"id":2,"course_section_id":4,"user_id":3,"type":"TeacherEnrollment","user":{"id":3,"name":"Leonard"}}
+		]`))
+	})
+
+	enrollments, err := s.ListEnrollments(OptStudent)
+	is.NoErr(err)
+	is.Equal(len(enrollments), 2)
+	is.Equal(enrollments[0].CourseSectionID, 4)
+	is.Equal(query["type[]"], []string{"StudentEnrollment"})
+	is.Equal(len(query["enrollment_type"]), 0)
+
+	users, err := s.ListUsers()
+	is.NoErr(err)
+	is.Equal(len(users), 2)
+	is.Equal(users[1].Name, "Leonard")
+	is.True(users[0].client != nil)
+	is.Equal(query["include[]"], []string{"user"})
+}
@@ -0,0 +1,432 @@
+package canvas
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Submission types that can be used when submitting an assignment.
+const (
+	OnlineTextEntry = "online_text_entry"
+	OnlineURL       = "online_url"
+	OnlineUpload    = "online_upload"
+	MediaRecording  = "media_recording"
+)
+
+// Submission is a submission type.
+type Submission struct {
+	ID int `json:"id"`
+	// A submission type can be any of:
+	//	- "online_text_entry"
+	//	- "online_url"
+	//	- "online_upload"
+	//	- "media_recording"
+	Type                          string              `json:"submission_type" url:"submission_type"`
+	AssignmentID                  int                 `json:"assignment_id"`
+	Assignment                    interface{}         `json:"assignment"`
+	Course                        interface{}         `json:"course"`
+	Attempt                       int                 `json:"attempt"`
+	Body                          string              `json:"body,omitempty"`
+	Grade                         string              `json:"grade"`
+	GradeMatchesCurrentSubmission bool                `json:"grade_matches_current_submission"`
+	HTMLURL                       string              `json:"html_url,omitempty"`
+	PreviewURL                    string              `json:"preview_url"`
+	Score                         float64             `json:"score"`
+	Comments                      []SubmissionComment `json:"submission_comments"`
+	SubmittedAt                   time.Time           `json:"submitted_at"`
+	PostedAt                      time.Time           `json:"posted_at"`
+	URL                           string              `json:"url,omitempty"`
+	GraderID                      int                 `json:"grader_id"`
+	GradedAt                      time.Time           `json:"graded_at"`
+	UserID                        int                 `json:"user_id"`
+	User                          interface{}         `json:"user" url:"-"`
+	Late                          bool                `json:"late"`
+	AssignmentVisible             bool                `json:"assignment_visible"`
+	Excused                       bool                `json:"excused"`
+	Missing                       bool                `json:"missing"`
+	LatePolicyStatus              string              `json:"late_policy_status"`
+	PointsDeducted                float64             `json:"points_deducted"`
+	SecondsLate                   int                 `json:"seconds_late"`
+	WorkflowState                 string              `json:"workflow_state"`
+	ExtraAttempts                 int                 `json:"extra_attempts"`
+	AnonymousID                   string              `json:"anonymous_id"`
+	Attachments                   []*File             `json:"attachments"`
+	RubricAssessment              RubricAssessment    `json:"rubric_assessment"`
+
+	// Used assignment submission
+	FileIDs          []int  `json:"-" url:"file_ids,omitempty"`
+	MediaCommentID   string `json:"-" url:"media_comment_id,omitempty"`
+	MediaCommentType string `json:"-" url:"media_comment_type,omitempty"` // "audio" or "video"
+
+	courseID int
+	client   doer
+}
+
+// WithContext returns a copy of the submission that uses ctx.
+func (s *Submission) WithContext(ctx context.Context) *Submission {
+	cp := *s
+	cp.client = withContext(s.client, ctx)
+	return &cp
+}
+
+// Submit will make a submission for the assignment. The submission type
+// decides which fields are sent:
+//   - OnlineTextEntry uses Body
+//   - OnlineURL uses URL
+//   - OnlineUpload uses FileIDs, the ids of files uploaded with SubmitFile
+//   - MediaRecording uses MediaCommentID and MediaCommentType
+//
+// https://canvas.instructure.com/doc/api/submissions.html#method.submissions.create
+func (a *Assignment) Submit(sub Submission, opts ...Option) (*Submission, error) {
+	q := params{"submission[submission_type]": {sub.Type}}
+	switch sub.Type {
+	case OnlineTextEntry:
+		q.Set("submission[body]", sub.Body)
+	case OnlineURL:
+		q.Set("submission[url]", sub.URL)
+	case OnlineUpload:
+		if len(sub.FileIDs) == 0 {
+			return nil, errors.New("no files to submit")
+		}
+		for _, id := range sub.FileIDs {
+			q["submission[file_ids][]"] = append(q["submission[file_ids][]"], strconv.Itoa(id))
+		}
+	case MediaRecording:
+		q.Set("submission[media_comment_id]", sub.MediaCommentID)
+		q.Set("submission[media_comment_type]", sub.MediaCommentType)
+	case "":
+		return nil, errors.New("no submission type")
+	default:
+		return nil, fmt.Errorf("cannot submit a submission of type %q", sub.Type)
+	}
+	q.Add(opts)
+	resp, err := post(a.client, fmt.Sprintf("/courses/%d/assignments/%d/submissions", a.CourseID, a.ID), q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	s := &Submission{courseID: a.CourseID}
+	if err = json.NewDecoder(resp.Body).Decode(s); err != nil {
+		return nil, err
+	}
+	s.setclient(a.client)
+	return s, nil
+}
+
+// Submissions will get all of the submissions for the assignment.
+//
+// https://canvas.instructure.com/doc/api/submissions.html#method.submissions_api.index
+func (a *Assignment) Submissions(opts ...Option) ([]*Submission, error) {
+	return collectSubmissions(a.SubmissionsIter(opts...))
+}
+
+// SubmissionsIter returns an iterator over the assignment's submissions.
+//
+// https://canvas.instructure.com/doc/api/submissions.html#method.submissions_api.index
+func (a *Assignment) SubmissionsIter(opts ...Option) *SubmissionIterator {
+	return newSubmissionIterator(
+		a.client,
+		fmt.Sprintf("/courses/%d/assignments/%d/submissions", a.CourseID, a.ID),
+		a.CourseID, opts,
+	)
+}
+
+// Submission will get the submission for one user.
+//
+// https://canvas.instructure.com/doc/api/submissions.html#method.submissions_api.show
+func (a *Assignment) Submission(userID int, opts ...Option) (*Submission, error) {
+	s := &Submission{courseID: a.CourseID}
+	err := getjson(
+		a.client, s, optEnc(opts),
+		"/courses/%d/assignments/%d/submissions/%d",
+		a.CourseID, a.ID, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	s.setclient(a.client)
+	return s, nil
+}
+
+// StudentSubmissions will get the submissions for multiple students and
+// assignments. If studentIDs is empty then the submissions for all
+// students are returned and if assignmentIDs is empty the submissions
+// for all assignments are returned.
+//
+// https://canvas.instructure.com/doc/api/submissions.html#method.submissions_api.for_students
+func (c *Course) StudentSubmissions(studentIDs, assignmentIDs []int, opts ...Option) ([]*Submission, error) {
+	return collectSubmissions(c.StudentSubmissionsIter(studentIDs, assignmentIDs, opts...))
+}
+
+// StudentSubmissionsIter returns an iterator over the submissions for
+// multiple students and assignments. See StudentSubmissions.
+//
+// https://canvas.instructure.com/doc/api/submissions.html#method.submissions_api.for_students
+func (c *Course) StudentSubmissionsIter(studentIDs, assignmentIDs []int, opts ...Option) *SubmissionIterator {
+	students := []string{"all"}
+	if len(studentIDs) > 0 {
+		students = intStrings(studentIDs)
+	}
+	opts = append(opts, ArrayOpt("student_ids", students...))
+	if len(assignmentIDs) > 0 {
+		opts = append(opts, ArrayOpt("assignment_ids", intStrings(assignmentIDs)...))
+	}
+	return newSubmissionIterator(c.client, c.id("/courses/%d/students/submissions"), c.ID, opts)
+}
+
+// SubmissionIterator iterates over a paginated list of submissions.
+type SubmissionIterator struct{ *iterator }
+
+// Value returns the current submission.
+func (it *SubmissionIterator) Value() *Submission {
+	s, _ := it.cur.(*Submission)
+	return s
+}
+
+func newSubmissionIterator(d doer, path string, courseID int, opts []Option) *SubmissionIterator {
+	return &SubmissionIterator{newIterator(d, path, opts, func(r io.Reader, emit emitFunc) error {
+		subs := make([]*Submission, 0, defaultPerPage)
+		if err := json.NewDecoder(r).Decode(&subs); err != nil {
+			return err
+		}
+		for _, s := range subs {
+			s.setclient(d)
+			s.courseID = courseID
+			if err := emit(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	})}
+}
+
+func (s *Submission) setclient(d doer) {
+	s.client = d
+	for _, f := range s.Attachments {
+		f.setclient(d)
+	}
+	for _, c := range s.Comments {
+		for _, f := range c.Attachments {
+			f.setclient(d)
+		}
+	}
+}
+
+// SubmissionComment is a comment on a submission.
+type SubmissionComment struct {
+	ID               int       `json:"id"`
+	AuthorID         int       `json:"author_id"`
+	AuthorName       string    `json:"author_name"`
+	Comment          string    `json:"comment"`
+	CreatedAt        time.Time `json:"created_at"`
+	EditedAt         time.Time `json:"edited_at"`
+	MediaCommentID   string    `json:"media_comment_id"`
+	MediaCommentType string    `json:"media_comment_type"`
+	Attachments      []*File   `json:"attachments"`
+	Author           struct {
+		ID             int    `json:"id"`
+		DisplayName    string `json:"display_name"`
+		AvatarImageURL string `json:"avatar_image_url"`
+		HTMLURL        string `json:"html_url"`
+	} `json:"author"`
+}
+
+// RubricAssessment maps rubric criterion ids to the
+// assessment for that criterion.
+type RubricAssessment map[string]CriterionAssessment
+
+// CriterionAssessment is the assessment of one rubric criterion.
+type CriterionAssessment struct {
+	Points   float64 `json:"points"`
+	RatingID string  `json:"rating_id,omitempty"`
+	Comments string  `json:"comments,omitempty"`
+}
+
+func (ra RubricAssessment) addTo(q params, prefix string) {
+	for id, c := range ra {
+		key := fmt.Sprintf("%s[%s]", prefix, id)
+		q.Set(key+"[points]", strconv.FormatFloat(c.Points, 'f', -1, 64))
+		if c.RatingID != "" {
+			q.Set(key+"[rating_id]", c.RatingID)
+		}
+		if c.Comments != "" {
+			q.Set(key+"[comments]", c.Comments)
+		}
+	}
+}
+
+// PostGrade will set the grade for the submission. The grade can be
+// a number of points, a percentage (ex. "85%"), a letter grade, or
+// "pass"/"complete" and "fail"/"incomplete" depending on the
+// assignment's grading type.
+//
+// https://canvas.instructure.com/doc/api/submissions.html#method.submissions_api.update
+func (s *Submission) PostGrade(grade string, opts ...Option) error {
+	q := params{"submission[posted_grade]": {grade}}
+	q.Add(opts)
+	return s.update(q)
+}
+
+// Excuse will excuse the student from the assignment.
+//
+// https://canvas.instructure.com/doc/api/submissions.html#method.submissions_api.update
+func (s *Submission) Excuse(opts ...Option) error {
+	q := params{"submission[excuse]": {"true"}}
+	q.Add(opts)
+	return s.update(q)
+}
+
+// AddComment will add a comment to the submission. Files
+// that are attached to the comment must first be uploaded
+// with UploadCommentFile.
+//
+// https://canvas.instructure.com/doc/api/submissions.html#method.submissions_api.update
+func (s *Submission) AddComment(text string, files ...*File) error {
+	q := params{"comment[text_comment]": {text}}
+	for _, f := range files {
+		q["comment[file_ids][]"] = append(q["comment[file_ids][]"], strconv.Itoa(f.ID))
+	}
+	return s.update(q)
+}
+
+// UploadCommentFile will upload a file that can be attached to a comment
+// on the submission using AddComment.
+//
+// https://canvas.instructure.com/doc/api/submission_comments.html#method.submission_comments_api.create_file
+func (s *Submission) UploadCommentFile(filename string, r io.Reader, opts ...Option) (*File, error) {
+	path, err := s.path()
+	if err != nil {
+		return nil, err
+	}
+	return uploadFile(s.client, r, path+"/comments/files", newFileUploadParams(filename, opts))
+}
+
+// Assess will submit a rubric assessment for the submission.
+//
+// https://canvas.instructure.com/doc/api/submissions.html#method.submissions_api.update
+func (s *Submission) Assess(assessment RubricAssessment, opts ...Option) error {
+	q := params{}
+	assessment.addTo(q, "rubric_assessment")
+	q.Add(opts)
+	return s.update(q)
+}
+
+func (s *Submission) update(q params) error {
+	path, err := s.path()
+	if err != nil {
+		return err
+	}
+	resp, err := put(s.client, path, q)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err = json.NewDecoder(resp.Body).Decode(s); err != nil {
+		return err
+	}
+	s.setclient(s.client)
+	return nil
+}
+
+func (s *Submission) path() (string, error) {
+	if s.courseID == 0 {
+		return "", errors.New("submission does not have a course id")
+	}
+	return fmt.Sprintf(
+		"/courses/%d/assignments/%d/submissions/%d",
+		s.courseID, s.AssignmentID, s.UserID,
+	), nil
+}
+
+// GradeData is the grading data for one student
+// used when updating grades in bulk.
+type GradeData struct {
+	PostedGrade      string
+	Excuse           bool
+	TextComment      string
+	GroupComment     bool
+	FileIDs          []int
+	RubricAssessment RubricAssessment
+}
+
+func (g *GradeData) addTo(q params, prefix string) {
+	if g.PostedGrade != "" {
+		q.Set(prefix+"[posted_grade]", g.PostedGrade)
+	}
+	if g.Excuse {
+		q.Set(prefix+"[excuse]", "true")
+	}
+	if g.TextComment != "" {
+		q.Set(prefix+"[text_comment]", g.TextComment)
+	}
+	if g.GroupComment {
+		q.Set(prefix+"[group_comment]", "true")
+	}
+	for _, id := range g.FileIDs {
+		q[prefix+"[file_ids][]"] = append(q[prefix+"[file_ids][]"], strconv.Itoa(id))
+	}
+	g.RubricAssessment.addTo(q, prefix+"[rubric_assessment]")
+}
+
+// BulkUpdateGrades will update the grades of many students for many
+// assignments at once. The grades map assignment ids to a map of student
+// ids to the new grade data. The returned Progress can be used to check
+// when the grades have been updated.
+//
+// https://canvas.instructure.com/doc/api/submissions.html#method.submissions_api.bulk_update
+func (c *Course) BulkUpdateGrades(grades map[int]map[int]GradeData, opts ...Option) (*Progress, error) {
+	q := params{}
+	for assignment, students := range grades {
+		for student, data := range students {
+			data.addTo(q, fmt.Sprintf("grade_data[%d][%d]", assignment, student))
+		}
+	}
+	q.Add(opts)
+	return bulkUpdateGrades(c.client, c.id("/courses/%d/submissions/update_grades"), q)
+}
+
+// BulkUpdateGrades will update the grades of many students at once. The
+// grades map student ids to the new grade data.
+//
+// https://canvas.instructure.com/doc/api/submissions.html#method.submissions_api.bulk_update
+func (a *Assignment) BulkUpdateGrades(grades map[int]GradeData, opts ...Option) (*Progress, error) {
+	q := params{}
+	for student, data := range grades {
+		data.addTo(q, fmt.Sprintf("grade_data[%d]", student))
+	}
+	q.Add(opts)
+	return bulkUpdateGrades(
+		a.client,
+		fmt.Sprintf("/courses/%d/assignments/%d/submissions/update_grades", a.CourseID, a.ID),
+		q,
+	)
+}
+
+func bulkUpdateGrades(d doer, path string, q params) (*Progress, error) {
+	resp, err := post(d, path, q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	p := &Progress{client: d}
+	return p, json.NewDecoder(resp.Body).Decode(p)
+}
+
+func collectSubmissions(it *SubmissionIterator) ([]*Submission, error) {
+	subs := make([]*Submission, 0)
+	err := collect(it.iterator, &subs)
+	return subs, err
+}
+
+func intStrings(ids []int) []string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = strconv.Itoa(id)
+	}
+	return s
+}
@@ -0,0 +1,175 @@
+package canvas
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestAssignment_Submissions(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	a := &Assignment{ID: 33, CourseID: 1, client: cli}
+
+	mux.HandleFunc("/api/v1/courses/1/assignments/33/submissions", handlePagingatedList(t, 3, "submission.json"))
+	mux.HandleFunc("/api/v1/courses/1/assignments/33/submissions/2", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "GET")
+		writeTestFile(t, "submission.json", w)
+	})
+	subs, err := a.Submissions()
+	is.NoErr(err)
+	is.Equal(len(subs), 3)
+	for _, s := range subs {
+		is.Equal(s.ID, 7)
+		is.Equal(s.courseID, 1)
+		is.True(s.client != nil)
+	}
+
+	sub, err := a.Submission(2)
+	is.NoErr(err)
+	is.Equal(sub.UserID, 2)
+	is.Equal(sub.Score, 9.5)
+	is.Equal(len(sub.Attachments), 1)
+	is.Equal(sub.Attachments[0].ID, 569)
+	is.True(sub.Attachments[0].client != nil)
+}
+
+func TestCourse_StudentSubmissions(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	c := &Course{ID: 1, client: cli}
+
+	var query map[string][]string
+	mux.HandleFunc("/api/v1/courses/1/students/submissions", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "GET")
+		query = r.URL.Query()
+		handlePagingatedList(t, 2, "submission.json")(w, r)
+	})
+	subs, err := c.StudentSubmissions(nil, nil)
+	is.NoErr(err)
+	is.Equal(len(subs), 2)
+	is.Equal(query["student_ids[]"], []string{"all"})
+	is.Equal(len(query["assignment_ids[]"]), 0)
+
+	_, err = c.StudentSubmissions([]int{2, 3}, []int{33})
+	is.NoErr(err)
+	is.Equal(query["student_ids[]"], []string{"2", "3"})
+	is.Equal(query["assignment_ids[]"], []string{"33"})
+}
+
+func TestAssignment_Submit(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	a := &Assignment{ID: 33, CourseID: 1, client: cli}
+
+	var query map[string][]string
+	mux.HandleFunc("/api/v1/courses/1/assignments/33/submissions", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "POST")
+		query = r.URL.Query()
+		writeTestFile(t, "submission.json", w)
+	})
+
+	_, err := a.Submit(Submission{Type: OnlineTextEntry, Body: "<p>hello</p>"})
+	is.NoErr(err)
+	is.Equal(query["submission[submission_type]"], []string{"online_text_entry"})
+	is.Equal(query["submission[body]"], []string{"<p>hello</p>"})
+
+	_, err = a.Submit(Submission{Type: OnlineURL, URL: "https://example.com"})
+	is.NoErr(err)
+	is.Equal(query["submission[url]"], []string{"https://example.com"})
+
+	sub, err := a.Submit(Submission{Type: OnlineUpload, FileIDs: []int{569, 570}}, Opt("comment[text_comment]", "done"))
+	is.NoErr(err)
+	is.Equal(query["submission[file_ids][]"], []string{"569", "570"})
+	is.Equal(query["comment[text_comment]"], []string{"done"})
+	is.Equal(sub.ID, 7)
+	is.True(sub.client != nil)
+
+	_, err = a.Submit(Submission{Type: MediaRecording, MediaCommentID: "m-1", MediaCommentType: "video"})
+	is.NoErr(err)
+	is.Equal(query["submission[media_comment_id]"], []string{"m-1"})
+	is.Equal(query["submission[media_comment_type]"], []string{"video"})
+
+	_, err = a.Submit(Submission{Type: OnlineUpload})
+	is.True(err != nil)
+	_, err = a.Submit(Submission{})
+	is.True(err != nil)
+}
+
+func TestSubmission_Grading(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	s := &Submission{AssignmentID: 33, UserID: 2, courseID: 1, client: cli}
+
+	var query map[string][]string
+	mux.HandleFunc("/api/v1/courses/1/assignments/33/submissions/2", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "PUT")
+		query = r.URL.Query()
+		writeTestFile(t, "submission.json", w)
+	})
+	is.NoErr(s.PostGrade("95%"))
+	is.Equal(query["submission[posted_grade]"], []string{"95%"})
+	is.Equal(s.ID, 7)
+	is.True(s.Attachments[0].client != nil)
+
+	is.NoErr(s.Excuse())
+	is.Equal(query["submission[excuse]"], []string{"true"})
+
+	is.NoErr(s.AddComment("nice work", &File{ID: 5}, &File{ID: 6}))
+	is.Equal(query["comment[text_comment]"], []string{"nice work"})
+	is.Equal(query["comment[file_ids][]"], []string{"5", "6"})
+
+	is.NoErr(s.Assess(RubricAssessment{
+		"crit_1": {Points: 2.5, Comments: "good"},
+		"crit_2": {Points: 0, RatingID: "r3"},
+	}))
+	is.Equal(query["rubric_assessment[crit_1][points]"], []string{"2.5"})
+	is.Equal(query["rubric_assessment[crit_1][comments]"], []string{"good"})
+	is.Equal(query["rubric_assessment[crit_2][points]"], []string{"0"})
+	is.Equal(query["rubric_assessment[crit_2][rating_id]"], []string{"r3"})
+	is.Equal(len(query["rubric_assessment[crit_2][comments]"]), 0)
+
+	is.True((&Submission{client: cli}).PostGrade("A") != nil)
+}
+
+func TestCourse_BulkUpdateGrades(t *testing.T) {
+	is := is.New(t)
+	cli, mux, server := testServer()
+	defer server.Close()
+	c := &Course{ID: 1, client: cli}
+
+	var query map[string][]string
+	mux.HandleFunc("/api/v1/courses/1/submissions/update_grades", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "POST")
+		query = r.URL.Query()
+		w.Write([]byte(`{"id":12,"workflow_state":"queued","completion":0,"url":"https://canvas.instructure.com/api/v1/progress/12"}`))
+	})
+	mux.HandleFunc("/api/v1/progress/12", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "GET")
+		w.Write([]byte(`{"id":12,"workflow_state":"completed","completion":100}`))
+	})
+
+	p, err := c.BulkUpdateGrades(map[int]map[int]GradeData{
+		33: {
+			2: {PostedGrade: "10", TextComment: "ok"},
+			3: {Excuse: true, FileIDs: []int{5}},
+		},
+	})
+	is.NoErr(err)
+	is.Equal(query["grade_data[33][2][posted_grade]"], []string{"10"})
+	is.Equal(query["grade_data[33][2][text_comment]"], []string{"ok"})
+	is.Equal(query["grade_data[33][3][excuse]"], []string{"true"})
+	is.Equal(query["grade_data[33][3][file_ids][]"], []string{"5"})
+	is.Equal(len(query["grade_data[33][3][posted_grade]"]), 0)
+	is.Equal(p.ID, 12)
+	is.Equal(p.WorkflowState, "queued")
+
+	is.NoErr(p.Refresh())
+	is.Equal(p.WorkflowState, "completed")
+	is.Equal(p.Completion, 100.0)
+}
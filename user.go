@@ -1,6 +1,7 @@
 package canvas
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"path"
@@ -22,7 +23,7 @@ type User struct {
 	CreatedAt       time.Time    `json:"created_at"`
 	LoginID         string       `json:"login_id"`
 	AvatarURL       string       `json:"avatar_url"`
-	Enrollments     []Enrollment `json:"enrollments"`
+	EnrollmentList  []Enrollment `json:"enrollments"`
 	Locale          string       `json:"locale"`
 	EffectiveLocale string       `json:"effective_locale"`
 	LastLogin       time.Time    `json:"last_login"`
@@ -37,6 +38,13 @@ type User struct {
 	client doer
 }
 
+// WithContext returns a copy of the user that uses ctx.
+func (u *User) WithContext(ctx context.Context) *User {
+	cp := *u
+	cp.client = withContext(u.client, ctx)
+	return &cp
+}
+
 // Settings will get the user's settings.
 func (u *User) Settings() (settings map[string]interface{}, err error) {
 	// TODO: find the settings json response and use a struct not a map
@@ -45,12 +53,17 @@ func (u *User) Settings() (settings map[string]interface{}, err error) {
 
 // Courses will return the user's courses.
 func (u *User) Courses(opts ...Option) ([]*Course, error) {
-	return getCourses(u.client, u.id("/users/%d/courses"), optEnc(opts))
+	return getCourses(u.client, u.id("/users/%d/courses"), opts)
+}
+
+// CoursesIter returns an iterator over the user's courses.
+func (u *User) CoursesIter(opts ...Option) *CourseIterator {
+	return newCourseIterator(u.client, u.id("/users/%d/courses"), opts)
 }
 
 // FavoriteCourses returns the user's list of favorites courses.
 func (u *User) FavoriteCourses(opts ...Option) ([]*Course, error) {
-	return getCourses(u.client, "/users/favorites/courses", optEnc(opts))
+	return getCourses(u.client, "/users/favorites/courses", opts)
 }
 
 // File will get a user's file by id
@@ -60,10 +73,12 @@ func (u *User) File(id int, opts ...Option) (*File, error) {
 
 // Files will return a channel of files.
 func (u *User) Files(opts ...Option) <-chan *File {
-	return filesChannel(
-		u.client, u.id("/users/%d/files"),
-		ConcurrentErrorHandler, opts, nil,
-	)
+	return filesChannel(u.FilesIter(opts...), ConcurrentErrorHandler)
+}
+
+// FilesIter returns an iterator over the user's files.
+func (u *User) FilesIter(opts ...Option) *FileIterator {
+	return newFileIterator(u.client, u.id("/users/%d/files"), nil, opts)
 }
 
 // ListFiles will collect all of the users files.
@@ -73,10 +88,12 @@ func (u *User) ListFiles(opts ...Option) ([]*File, error) {
 
 // Folders returns a channel of the user's folders.
 func (u *User) Folders(opts ...Option) <-chan *Folder {
-	return foldersChannel(
-		u.client, u.id("/users/%d/folders"),
-		ConcurrentErrorHandler, opts, nil,
-	)
+	return foldersChannel(u.FoldersIter(opts...), ConcurrentErrorHandler)
+}
+
+// FoldersIter returns an iterator over the user's folders.
+func (u *User) FoldersIter(opts ...Option) *FolderIterator {
+	return newFolderIterator(u.client, u.id("/users/%d/folders"), nil, opts)
 }
 
 // Root will get the root folder for the user's files.
@@ -174,49 +191,6 @@ func (u *User) GradedSubmissions() (subs []*Submission, err error) {
 	return subs, getjson(u.client, &subs, nil, "/users/%d/graded_submissions", u.ID)
 }
 
-// Submission is a submission type.
-type Submission struct {
-	// A submission type can be any of:
-	//	- "online_text_entry"
-	//	- "online_url"
-	//	- "online_upload"
-	//	- "media_recording"
-	Type                          string      `json:"submission_type" url:"submission_type"`
-	AssignmentID                  int         `json:"assignment_id"`
-	Assignment                    interface{} `json:"assignment"`
-	Course                        interface{} `json:"course"`
-	Attempt                       int         `json:"attempt"`
-	Body                          string      `json:"body,omitempty"`
-	Grade                         string      `json:"grade"`
-	GradeMatchesCurrentSubmission bool        `json:"grade_matches_current_submission"`
-	HTMLURL                       string      `json:"html_url,omitempty"`
-	PreviewURL                    string      `json:"preview_url"`
-	Score                         float64     `json:"score"`
-	Comments                      interface{} `json:"submission_comments"`
-	SubmittedAt                   time.Time   `json:"submitted_at"`
-	PostedAt                      time.Time   `json:"posted_at"`
-	URL                           string      `json:"url,omitempty"`
-	GraderID                      int         `json:"grader_id"`
-	GradedAt                      time.Time   `json:"graded_at"`
-	UserID                        int         `json:"user_id"`
-	User                          interface{} `json:"user" url:"-"`
-	Late                          bool        `json:"late"`
-	AssignmentVisible             bool        `json:"assignment_visible"`
-	Excused                       bool        `json:"excused"`
-	Missing                       bool        `json:"missing"`
-	LatePolicyStatus              string      `json:"late_policy_status"`
-	PointsDeducted                float64     `json:"points_deducted"`
-	SecondsLate                   int         `json:"seconds_late"`
-	WorkflowState                 string      `json:"workflow_state"`
-	ExtraAttempts                 int         `json:"extra_attempts"`
-	AnonymousID                   string      `json:"anonymous_id"`
-
-	// Used assignment submission
-	FileIDs          []int  `json:"-" url:"file_ids,omitempty"`
-	MediaCommentID   string `json:"-" url:"media_comment_id,omitempty"`
-	MediaCommentType string `json:"-" url:"media_comment_type,omitempty"` // "audio" or "video"
-}
-
 // Avatars will get a list of the user's avatars.
 func (u *User) Avatars() (av []Avatar, err error) {
 	return av, getjson(u.client, &av, nil, "/users/%d/avatars", u.ID)
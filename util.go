@@ -13,6 +13,9 @@ type encoder interface {
 
 func (p params) Add(vals []Option) {
 	for _, v := range vals {
+		if v.Name() == "" {
+			continue
+		}
 		p[v.Name()] = v.Value()
 	}
 }
@@ -37,6 +40,8 @@ func pathFromContextType(contextType string) string {
 		return "group_categories"
 	case "Account":
 		return "accounts"
+	case "Group":
+		return "groups"
 	default:
 		return ""
 	}